@@ -1,31 +1,52 @@
 package ecto
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"net/netip"
+	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/egsam98/errors"
+	"github.com/google/uuid"
 	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
 	"golang.org/x/exp/constraints"
 )
 
 var _ Schema = (*AtomicSchema[any, any])(nil)
 var _ IAtomicOrPtrSchema = (*AtomicSchema[any, any])(nil)
+var _ TypedSchema[any] = (*AtomicSchema[any, any])(nil)
 var typeStringer = reflect.TypeFor[fmt.Stringer]()
 var typeJsonNumber = reflect.TypeFor[json.Number]()
+var typeDecimal = reflect.TypeFor[decimal.Decimal]()
+var typeTime = reflect.TypeFor[time.Time]()
+var typeDuration = reflect.TypeFor[time.Duration]()
+var typeUUID = reflect.TypeFor[uuid.UUID]()
+var typeAddr = reflect.TypeFor[netip.Addr]()
+var typePrefix = reflect.TypeFor[netip.Prefix]()
+var typeURL = reflect.TypeFor[url.URL]()
 
 // AtomicSchema is a schema designed to describe scalar Go types or those that do not need to be recursively
 // processed internally (ex. decimal.Decimal).
 // Features:
 // - Convert input data into another (from T to R) for further validations
 // - Mark as required (check if value is Go zero-value)
-// - Set default if value is zero
+// - Set default if value is zero (constant or generated per call)
+// - Transform input data in place (ex. normalization)
 // - Set of Test predicates for validation
 type AtomicSchema[T comparable, R any] struct {
 	required, omitZero bool
+	defaultFunc        func(context.Context) (T, error)
 	defaultValue       *T
+	transforms         []func(T) (T, error)
 	convert            func(*T) (*R, error)
+	isZero             func(*T) bool
 	tests              []Test[R]
 }
 
@@ -40,9 +61,16 @@ func AtomicFrom[T comparable, R any](convert func(*T) (*R, error)) AtomicSchema[
 // Int shorthand
 func Int() AtomicSchema[int, int] { return Atomic[int]() }
 
-// IntFrom shorthand with conversion
+// IntFrom shorthand with conversion. Values that don't fit into int (ex. large uint64) fail conversion
+// instead of wrapping around
 func IntFrom[T constraints.Integer]() AtomicSchema[T, int] {
-	return AtomicFrom[T, int](func(t *T) (*int, error) { return lo.ToPtr(int(*t)), nil })
+	return AtomicFrom[T, int](func(t *T) (*int, error) {
+		i, ok := castInt[int](*t)
+		if !ok {
+			return nil, errors.New("integer out of range")
+		}
+		return &i, nil
+	})
 }
 
 // String shorthand
@@ -74,26 +102,285 @@ func Float() AtomicSchema[float64, float64] { return Atomic[float64]() }
 
 // FloatFrom shorthand with conversion. Supported types in order:
 // - float32/64 and its type definitions
-// - json.Number
-func FloatFrom[T comparable]() AtomicSchema[T, float64] {
+// - json.Number, string and its type definitions
+//
+// Digits tests (ex. decimals.MaxScale, decimals.MaxPrecision, decimals.MaxIntegerDigits) are applied to exact
+// decimal representation of textual input before conversion, so "0.30000000000000001" is checked as written.
+// Floats are checked by their shortest representation. Error of the first failed test fails conversion
+func FloatFrom[T comparable](digits ...Test[decimal.Decimal]) AtomicSchema[T, float64] {
 	rt := reflect.TypeFor[T]()
 
 	var convert func(*T) (*float64, error)
 	switch kind := rt.Kind(); {
-	case kind == reflect.Float32 || kind == reflect.Float64:
+	case kind == reflect.Float32:
 		convert = func(v *T) (*float64, error) {
-			return lo.ToPtr(reflect.ValueOf(*v).Float()), nil
+			f := reflect.ValueOf(*v).Float()
+			return &f, checkDigits(decimal.NewFromFloat32(float32(f)), digits)
 		}
-	case rt == typeJsonNumber:
+	case kind == reflect.Float64:
+		convert = func(v *T) (*float64, error) {
+			f := reflect.ValueOf(*v).Float()
+			return &f, checkDigits(decimal.NewFromFloat(f), digits)
+		}
+	case kind == reflect.String:
 		convert = func(t *T) (*float64, error) {
-			f, err := any(*t).(json.Number).Float64()
+			s := reflect.ValueOf(*t).String()
+			f, err := strconv.ParseFloat(s, 64)
 			if err != nil {
 				return nil, errors.New("invalid number")
 			}
-			return &f, nil
+			if len(digits) == 0 {
+				return &f, nil
+			}
+			d, err := decimal.NewFromString(s)
+			if err != nil {
+				return nil, errors.New("invalid number")
+			}
+			return &f, checkDigits(d, digits)
 		}
 	default:
-		panic(errors.Errorf("%s is neither float32/64 nor %s", rt, typeJsonNumber))
+		panic(errors.Errorf("%s is neither float32/64, %s nor string", rt, typeJsonNumber))
+	}
+
+	return AtomicFrom(convert)
+}
+
+// checkDigits returns error of the first test failed by d
+func checkDigits(d decimal.Decimal, tests []Test[decimal.Decimal]) error {
+	for _, test := range tests {
+		if err := test.Run(&d); err != nil {
+			return errors.New(string(*err))
+		}
+	}
+	return nil
+}
+
+// DecimalFrom shorthand with conversion. Supported types in order:
+// - decimal.Decimal
+// - json.Number, string and its type definitions
+// - float32/64 and its type definitions
+func DecimalFrom[T comparable]() AtomicSchema[T, decimal.Decimal] {
+	rt := reflect.TypeFor[T]()
+
+	var convert func(*T) (*decimal.Decimal, error)
+	switch kind := rt.Kind(); {
+	case rt == typeDecimal:
+		schema := AtomicFrom(func(v *T) (*decimal.Decimal, error) {
+			return lo.ToPtr(any(*v).(decimal.Decimal)), nil
+		})
+		// Numerically equal decimals may differ in representation, so zero is checked by value
+		schema.isZero = func(v *T) bool { return any(*v).(decimal.Decimal).IsZero() }
+		return schema
+	case kind == reflect.String:
+		convert = func(v *T) (*decimal.Decimal, error) {
+			d, err := decimal.NewFromString(reflect.ValueOf(*v).String())
+			if err != nil {
+				return nil, errors.New("invalid decimal")
+			}
+			return &d, nil
+		}
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		convert = func(v *T) (*decimal.Decimal, error) {
+			f := reflect.ValueOf(*v).Float()
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				return nil, errors.New("invalid decimal")
+			}
+			if kind == reflect.Float32 {
+				return lo.ToPtr(decimal.NewFromFloat32(float32(f))), nil
+			}
+			return lo.ToPtr(decimal.NewFromFloat(f)), nil
+		}
+	default:
+		panic(errors.Errorf("%s is neither %s, string nor float32/64", rt, typeDecimal))
+	}
+
+	return AtomicFrom(convert)
+}
+
+// TimeFrom shorthand with conversion. Supported types in order:
+// - time.Time
+// - string and its type definitions parsed by layouts one by one (time.RFC3339 if none provided)
+func TimeFrom[T comparable](layouts ...string) AtomicSchema[T, time.Time] {
+	rt := reflect.TypeFor[T]()
+	if len(layouts) == 0 {
+		layouts = []string{time.RFC3339}
+	}
+
+	var convert func(*T) (*time.Time, error)
+	switch {
+	case rt == typeTime:
+		convert = func(v *T) (*time.Time, error) { return lo.ToPtr(any(*v).(time.Time)), nil }
+	case rt.Kind() == reflect.String:
+		convert = func(v *T) (*time.Time, error) {
+			str := reflect.ValueOf(*v).String()
+			for _, layout := range layouts {
+				if t, err := time.Parse(layout, str); err == nil {
+					return &t, nil
+				}
+			}
+			return nil, errors.Errorf("datetime format must be %s", strings.Join(layouts, " or "))
+		}
+	default:
+		panic(errors.Errorf("%s is neither %s nor string", rt, typeTime))
+	}
+
+	return AtomicFrom(convert)
+}
+
+// UnixFrom shorthand with conversion of Unix timestamp in seconds into UTC time
+func UnixFrom[T constraints.Integer]() AtomicSchema[T, time.Time] {
+	return AtomicFrom[T, time.Time](func(v *T) (*time.Time, error) {
+		sec, ok := castInt[int64](*v)
+		if !ok {
+			return nil, errors.New("integer out of range")
+		}
+		return lo.ToPtr(time.Unix(sec, 0).UTC()), nil
+	})
+}
+
+// UnixMilliFrom shorthand with conversion of Unix timestamp in milliseconds into UTC time
+func UnixMilliFrom[T constraints.Integer]() AtomicSchema[T, time.Time] {
+	return AtomicFrom[T, time.Time](func(v *T) (*time.Time, error) {
+		msec, ok := castInt[int64](*v)
+		if !ok {
+			return nil, errors.New("integer out of range")
+		}
+		return lo.ToPtr(time.UnixMilli(msec).UTC()), nil
+	})
+}
+
+// DurationFrom shorthand with conversion. Supported types in order:
+// - time.Duration
+// - string and its type definitions (see time.ParseDuration)
+func DurationFrom[T comparable]() AtomicSchema[T, time.Duration] {
+	rt := reflect.TypeFor[T]()
+
+	var convert func(*T) (*time.Duration, error)
+	switch {
+	case rt == typeDuration:
+		convert = func(v *T) (*time.Duration, error) { return lo.ToPtr(any(*v).(time.Duration)), nil }
+	case rt.Kind() == reflect.String:
+		convert = func(v *T) (*time.Duration, error) {
+			d, err := time.ParseDuration(reflect.ValueOf(*v).String())
+			if err != nil {
+				return nil, errors.New("invalid duration")
+			}
+			return &d, nil
+		}
+	default:
+		panic(errors.Errorf("%s is neither %s nor string", rt, typeDuration))
+	}
+
+	return AtomicFrom(convert)
+}
+
+// UUIDFrom shorthand with conversion. Supported types in order:
+// - uuid.UUID
+// - string and its type definitions (see uuid.Parse)
+// - [16]byte and its type definitions
+//
+// []byte isn't supported since AtomicSchema requires comparable types, parse it via uuid.FromBytes
+// in advance or use AtomicFrom
+func UUIDFrom[T comparable]() AtomicSchema[T, uuid.UUID] {
+	rt := reflect.TypeFor[T]()
+
+	var convert func(*T) (*uuid.UUID, error)
+	switch kind := rt.Kind(); {
+	case rt == typeUUID:
+		convert = func(v *T) (*uuid.UUID, error) { return lo.ToPtr(any(*v).(uuid.UUID)), nil }
+	case kind == reflect.String:
+		convert = func(v *T) (*uuid.UUID, error) {
+			id, err := uuid.Parse(reflect.ValueOf(*v).String())
+			if err != nil {
+				return nil, errors.New("invalid UUID")
+			}
+			return &id, nil
+		}
+	case kind == reflect.Array && rt.Len() == len(uuid.UUID{}) && rt.Elem().Kind() == reflect.Uint8:
+		convert = func(v *T) (*uuid.UUID, error) {
+			id, err := uuid.FromBytes(reflect.ValueOf(v).Elem().Bytes())
+			if err != nil {
+				return nil, errors.New("invalid UUID")
+			}
+			return &id, nil
+		}
+	default:
+		panic(errors.Errorf("%s is neither %s, string nor [16]byte", rt, typeUUID))
+	}
+
+	return AtomicFrom(convert)
+}
+
+// AddrFrom shorthand with conversion. Supported types in order:
+// - netip.Addr
+// - string and its type definitions (see netip.ParseAddr)
+func AddrFrom[T comparable]() AtomicSchema[T, netip.Addr] {
+	rt := reflect.TypeFor[T]()
+
+	var convert func(*T) (*netip.Addr, error)
+	switch {
+	case rt == typeAddr:
+		convert = func(v *T) (*netip.Addr, error) { return lo.ToPtr(any(*v).(netip.Addr)), nil }
+	case rt.Kind() == reflect.String:
+		convert = func(v *T) (*netip.Addr, error) {
+			addr, err := netip.ParseAddr(reflect.ValueOf(*v).String())
+			if err != nil {
+				return nil, errors.New("invalid IP address")
+			}
+			return &addr, nil
+		}
+	default:
+		panic(errors.Errorf("%s is neither %s nor string", rt, typeAddr))
+	}
+
+	return AtomicFrom(convert)
+}
+
+// PrefixFrom shorthand with conversion. Supported types in order:
+// - netip.Prefix
+// - string and its type definitions in CIDR notation (see netip.ParsePrefix)
+func PrefixFrom[T comparable]() AtomicSchema[T, netip.Prefix] {
+	rt := reflect.TypeFor[T]()
+
+	var convert func(*T) (*netip.Prefix, error)
+	switch {
+	case rt == typePrefix:
+		convert = func(v *T) (*netip.Prefix, error) { return lo.ToPtr(any(*v).(netip.Prefix)), nil }
+	case rt.Kind() == reflect.String:
+		convert = func(v *T) (*netip.Prefix, error) {
+			prefix, err := netip.ParsePrefix(reflect.ValueOf(*v).String())
+			if err != nil {
+				return nil, errors.New("invalid CIDR")
+			}
+			return &prefix, nil
+		}
+	default:
+		panic(errors.Errorf("%s is neither %s nor string", rt, typePrefix))
+	}
+
+	return AtomicFrom(convert)
+}
+
+// URLFrom shorthand with conversion. Supported types in order:
+// - url.URL
+// - string and its type definitions (see url.Parse)
+func URLFrom[T comparable]() AtomicSchema[T, url.URL] {
+	rt := reflect.TypeFor[T]()
+
+	var convert func(*T) (*url.URL, error)
+	switch {
+	case rt == typeURL:
+		convert = func(v *T) (*url.URL, error) { return lo.ToPtr(any(*v).(url.URL)), nil }
+	case rt.Kind() == reflect.String:
+		convert = func(v *T) (*url.URL, error) {
+			uri, err := url.Parse(reflect.ValueOf(*v).String())
+			if err != nil {
+				return nil, errors.New("invalid URL")
+			}
+			return uri, nil
+		}
+	default:
+		panic(errors.Errorf("%s is neither %s nor string", rt, typeURL))
 	}
 
 	return AtomicFrom(convert)
@@ -112,32 +399,98 @@ func (s AtomicSchema[T, R]) OmitZero() AtomicSchema[T, R] {
 }
 
 func (s AtomicSchema[T, R]) Default(value T) AtomicSchema[T, R] {
+	s.defaultFunc = func(context.Context) (T, error) { return value, nil }
 	s.defaultValue = &value
 	return s
 }
 
+// DefaultFunc sets default generated on every Process call (ex. uuid.New, time.Now).
+// Use DefaultContext for generators that may fail
+func (s AtomicSchema[T, R]) DefaultFunc(fn func() T) AtomicSchema[T, R] {
+	s.defaultFunc = func(context.Context) (T, error) { return fn(), nil }
+	s.defaultValue = nil
+	return s
+}
+
+// DefaultContext sets default generated on every Process call with context passed to ProcessContext
+// (ex. tenant settings, request time). Generator error fails processing
+func (s AtomicSchema[T, R]) DefaultContext(fn func(ctx context.Context) (T, error)) AtomicSchema[T, R] {
+	s.defaultFunc = fn
+	s.defaultValue = nil
+	return s
+}
+
+// Transform sets functions replacing input data (ex. normalization) before conversion and tests.
+// Transform error fails processing
+func (s AtomicSchema[T, R]) Transform(fns ...func(T) (T, error)) AtomicSchema[T, R] {
+	s.transforms = fns
+	return s
+}
+
 func (s AtomicSchema[T, R]) Test(tests ...Test[R]) AtomicSchema[T, R] {
 	s.tests = tests
 	return s
 }
 
 // Process may return ListError
-func (s AtomicSchema[T, R]) Process(data *T) error { return s.process(data) }
+func (s AtomicSchema[T, R]) Process(data *T) error { return s.process(context.Background(), data) }
+
+// ProcessContext is Process with context passed to default providers (see DefaultContext)
+func (s AtomicSchema[T, R]) ProcessContext(ctx context.Context, data *T) error {
+	return s.process(ctx, data)
+}
 
 func (AtomicSchema[T, R]) ForType() reflect.Type { return reflect.TypeFor[T]() }
 
-func (s AtomicSchema[T, R]) process(ptrAny any) error {
+func (AtomicSchema[T, R]) typed(T) {}
+
+func (s AtomicSchema[T, R]) describe(*describer) Node {
+	node := Node{
+		Kind:       KindAtomic,
+		Type:       reflect.TypeFor[T]().String(),
+		Required:   s.required,
+		OmitZero:   s.omitZero,
+		Default:    newDefaultNode(s.defaultFunc != nil, s.defaultValue),
+		Transforms: len(s.transforms),
+		Tests:      lo.Map(s.tests, func(test Test[R], _ int) TestNode { return newTestNode(test) }),
+	}
+	if result := reflect.TypeFor[R]().String(); result != node.Type {
+		node.Result = result
+	}
+	return node
+}
+
+func (s AtomicSchema[T, R]) zero(v *T) bool {
+	if s.isZero != nil {
+		return s.isZero(v)
+	}
+	return lo.IsEmpty(*v)
+}
+
+func (s AtomicSchema[T, R]) process(ctx context.Context, ptrAny any) error {
 	ptr := ptrAny.(*T)
-	if lo.IsEmpty(*ptr) {
+	if s.zero(ptr) {
 		if s.required {
 			return ListError{errRequired}
 		}
 		if s.omitZero {
 			return nil
 		}
-		if s.defaultValue != nil {
-			*ptr = *s.defaultValue
+		if s.defaultFunc != nil {
+			value, err := s.defaultFunc(ctx)
+			if err != nil {
+				return ListError{Error(err.Error())}
+			}
+			*ptr = value
+		}
+	}
+
+	for _, transform := range s.transforms {
+		value, err := transform(*ptr)
+		if err != nil {
+			return ListError{Error(err.Error())}
 		}
+		*ptr = value
 	}
 
 	ptrConv, err := s.convert(ptr)
@@ -147,7 +500,7 @@ func (s AtomicSchema[T, R]) process(ptrAny any) error {
 
 	var errs ListError
 	for _, test := range s.tests {
-		if err := test.Run(ptrConv); err != nil {
+		if err := test.RunContext(ctx, ptrConv); err != nil {
 			errs = append(errs, *err)
 		}
 	}
@@ -164,3 +517,9 @@ func (s AtomicSchema[T, R]) WithRequired(value bool) IAtomicOrPtrSchema {
 	s.omitZero = !value
 	return s
 }
+
+// castInt converts integer T into I reporting whether the value has been preserved
+func castInt[I, T constraints.Integer](v T) (I, bool) {
+	i := I(v)
+	return i, T(i) == v && (i < 0) == (v < 0)
+}
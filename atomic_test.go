@@ -1,13 +1,27 @@
 package ecto_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/egsam98/ecto"
+	"github.com/egsam98/ecto/decimals"
+	"github.com/egsam98/ecto/floats"
+	integer "github.com/egsam98/ecto/ints"
+	"github.com/egsam98/ecto/network"
+	ectos "github.com/egsam98/ecto/strings"
+	"github.com/egsam98/ecto/times"
+	"github.com/egsam98/ecto/uuids"
 )
 
 func TestAtomic(t *testing.T) {
@@ -30,6 +44,24 @@ func TestAtomic(t *testing.T) {
 		assert.NoError(t, schema.Process(&v))
 		assert.Equal(t, 1, v)
 	})
+
+	t.Run("default func", func(t *testing.T) {
+		schema := ecto.Atomic[uuid.UUID]().DefaultFunc(uuid.New)
+		var v1, v2 uuid.UUID
+		assert.NoError(t, schema.Process(&v1))
+		assert.NoError(t, schema.Process(&v2))
+		assert.NotEqual(t, v1, v2)
+
+		now := ecto.Atomic[time.Time]().DefaultFunc(time.Now)
+		var at time.Time
+		assert.NoError(t, now.Process(&at))
+		assert.False(t, at.IsZero())
+
+		schema = ecto.Atomic[uuid.UUID]().DefaultContext(func(context.Context) (uuid.UUID, error) {
+			return uuid.Nil, errors.New("no entropy")
+		})
+		assert.EqualError(t, schema.Process(new(uuid.UUID)), `["no entropy"]`)
+	})
 }
 
 func TestAtomicFrom(t *testing.T) {
@@ -41,3 +73,190 @@ func TestAtomicFrom(t *testing.T) {
 	assert.NoError(t, schema.Process(lo.ToPtr("1")))
 	assert.EqualError(t, schema.Process(lo.ToPtr("a")), `["strconv.Atoi: parsing \"a\": invalid syntax"]`)
 }
+
+func TestIntFrom(t *testing.T) {
+	schema := ecto.IntFrom[uint64]()
+
+	assert.NoError(t, schema.Process(lo.ToPtr[uint64](math.MaxInt64)))
+	assert.EqualError(t, schema.Process(lo.ToPtr[uint64](math.MaxUint64)), `["integer out of range"]`)
+
+	t.Run("signed", func(t *testing.T) {
+		schema := ecto.IntFrom[int8]().Test(integer.Between[int](-5, 5))
+		assert.NoError(t, schema.Process(lo.ToPtr[int8](-5)))
+		assert.EqualError(t, schema.Process(lo.ToPtr[int8](-6)), `["must be between -5 and 5"]`)
+	})
+}
+
+func TestDecimalFrom(t *testing.T) {
+	schema := ecto.DecimalFrom[json.Number]().Test(decimals.MinorUnits("USD"), decimals.Positive())
+
+	assert.NoError(t, schema.Process(lo.ToPtr(json.Number("10.50"))))
+	assert.EqualError(t, schema.Process(lo.ToPtr(json.Number("a"))), `["invalid decimal"]`)
+	assert.EqualError(t, schema.Process(lo.ToPtr(json.Number("-0.001"))),
+		`["must have at most 2 decimal places for USD","must be positive"]`)
+	assert.Panics(t, func() { ecto.DecimalFrom[int]() })
+
+	t.Run("float", func(t *testing.T) {
+		schema := ecto.DecimalFrom[float64]().Test(decimals.MaxPrecision(3))
+		assert.NoError(t, schema.Process(lo.ToPtr(0.125)))
+		assert.EqualError(t, schema.Process(lo.ToPtr(10.125)), `["must have at most 3 digits"]`)
+		assert.EqualError(t, schema.Process(lo.ToPtr(math.NaN())), `["invalid decimal"]`)
+	})
+
+	t.Run("decimal", func(t *testing.T) {
+		schema := ecto.DecimalFrom[decimal.Decimal]().Required().Test(decimals.OneOf(decimal.New(1, 0), decimal.New(5, -1)))
+		assert.NoError(t, schema.Process(lo.ToPtr(decimal.RequireFromString("1.0"))))
+		assert.NoError(t, schema.Process(lo.ToPtr(decimal.RequireFromString("0.50"))))
+		assert.EqualError(t, schema.Process(lo.ToPtr(decimal.RequireFromString("2"))), `["must be one of [1 0.5]"]`)
+		assert.EqualError(t, schema.Process(lo.ToPtr(decimal.RequireFromString("0.00"))), `["required"]`)
+		assert.EqualError(t, schema.Process(&decimal.Decimal{}), `["required"]`)
+	})
+}
+
+func TestTimeFrom(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	schema := ecto.TimeFrom[string](time.DateOnly, time.RFC3339).
+		Test(times.Clock(func() time.Time { return now }).MinAge(18))
+
+	assert.NoError(t, schema.Process(lo.ToPtr("2007-06-15")))
+	assert.NoError(t, schema.Process(lo.ToPtr("2000-01-01T00:00:00Z")))
+	assert.EqualError(t, schema.Process(lo.ToPtr("2007-06-16")), `["must be at least 18 years old"]`)
+	assert.EqualError(t, schema.Process(lo.ToPtr("15.06.2007")),
+		`["datetime format must be 2006-01-02 or 2006-01-02T15:04:05Z07:00"]`)
+
+	t.Run("unix", func(t *testing.T) {
+		schema := ecto.UnixMilliFrom[uint64]().Test(times.Before(now))
+		assert.NoError(t, schema.Process(lo.ToPtr(uint64(now.UnixMilli()-1))))
+		assert.EqualError(t, schema.Process(lo.ToPtr(uint64(now.UnixMilli()))), `["must be before 2025-06-15T12:00:00Z"]`)
+		assert.EqualError(t, schema.Process(lo.ToPtr[uint64](math.MaxUint64)), `["integer out of range"]`)
+
+		utc := ecto.Test[time.Time]{Error: "not UTC", Func: func(v *time.Time) bool { return v.Location() == time.UTC }}
+		assert.NoError(t, ecto.UnixFrom[int64]().Test(utc).Process(lo.ToPtr(now.Unix())))
+		assert.NoError(t, ecto.UnixMilliFrom[int64]().Test(utc).Process(lo.ToPtr(now.UnixMilli())))
+	})
+
+	t.Run("clock from context", func(t *testing.T) {
+		schema := ecto.TimeFrom[string](time.DateOnly).Test(times.MinAge(18), times.NotInFuture())
+		ctx := times.WithClock(context.Background(), func() time.Time { return now })
+		assert.NoError(t, schema.ProcessContext(ctx, lo.ToPtr("2007-06-15")))
+		assert.EqualError(t, schema.ProcessContext(ctx, lo.ToPtr("2007-06-16")), `["must be at least 18 years old"]`)
+		assert.EqualError(t, schema.ProcessContext(ctx, lo.ToPtr("2025-06-16")),
+			`["must be at least 18 years old","must not be in the future"]`)
+		assert.NoError(t, schema.Process(lo.ToPtr("2007-06-16")))
+	})
+}
+
+func TestDurationFrom(t *testing.T) {
+	schema := ecto.DurationFrom[string]().Test(times.MaxDuration(time.Minute))
+
+	assert.NoError(t, schema.Process(lo.ToPtr("30s")))
+	assert.EqualError(t, schema.Process(lo.ToPtr("2m")), `["must be 1m0s maximum"]`)
+	assert.EqualError(t, schema.Process(lo.ToPtr("1")), `["invalid duration"]`)
+}
+
+func TestFloatFrom(t *testing.T) {
+	schema := ecto.FloatFrom[json.Number]().Test(floats.Max(0.3), floats.MaxPrecision(1))
+
+	assert.NoError(t, schema.Process(lo.ToPtr(json.Number("0.3"))))
+	assert.NoError(t, ecto.Float().Test(floats.Max(0.3), floats.MaxPrecision(1)).Process(lo.ToPtr(0.1+0.2)))
+	assert.EqualError(t, schema.Process(lo.ToPtr(json.Number("0.31"))),
+		`["must be 0.3 maximum","has more than 1 precision digits"]`)
+	assert.EqualError(t, schema.Process(lo.ToPtr(json.Number("a"))), `["invalid number"]`)
+	assert.NoError(t, schema.Process(lo.ToPtr(json.Number("3e-1"))))
+
+	// Textual input of any precision is accepted unless digits are restricted
+	assert.NoError(t, ecto.FloatFrom[json.Number]().Process(lo.ToPtr(json.Number("0.12345678901234567"))))
+	assert.NoError(t, ecto.FloatFrom[string]().Process(lo.ToPtr("9007199254740993")))
+
+	t.Run("digits", func(t *testing.T) {
+		schema := ecto.FloatFrom[json.Number](decimals.MaxScale(2), decimals.MaxIntegerDigits(3), decimals.MaxPrecision(4))
+		for value, err := range map[json.Number]string{
+			"123.4":               "",
+			"12.34":               "",
+			"-12.30":              "", // Trailing zeros are ignored
+			"1.5e2":               "",
+			"0.30000000000000001": `["must have at most 2 decimal places"]`,
+			"1.234":               `["must have at most 2 decimal places"]`,
+			"1234":                `["must have at most 3 integer digits"]`,
+			"123.45":              `["must have at most 4 digits"]`,
+			"9007199254740993":    `["must have at most 3 integer digits"]`,
+			"a":                   `["invalid number"]`,
+		} {
+			if err == "" {
+				assert.NoError(t, schema.Process(&value), value)
+			} else {
+				assert.EqualError(t, schema.Process(&value), err, value)
+			}
+		}
+
+		// Floats are checked by their shortest representation
+		a, b := 0.1, 0.2
+		floatSchema := ecto.FloatFrom[float64](decimals.MaxScale(2))
+		assert.NoError(t, floatSchema.Process(lo.ToPtr(0.25)))
+		assert.EqualError(t, floatSchema.Process(lo.ToPtr(a+b)), `["must have at most 2 decimal places"]`)
+		assert.NoError(t, ecto.FloatFrom[float32](decimals.MaxScale(1)).Process(lo.ToPtr[float32](0.1)))
+	})
+
+	t.Run("bounds", func(t *testing.T) {
+		a, b := 0.1, 0.2
+		bound := a + b // 0.30000000000000004
+		schema := ecto.Float().Test(floats.Min(bound), floats.Max(bound))
+		assert.NoError(t, schema.Process(lo.ToPtr(bound)))
+		assert.EqualError(t, schema.Process(lo.ToPtr(0.3)), `["must be 0.30000000000000004 minimum"]`)
+		assert.EqualError(t, schema.Process(lo.ToPtr(0.29999999999999)), `["must be 0.30000000000000004 minimum"]`)
+		assert.EqualError(t, schema.Process(lo.ToPtr(0.30000000000001)), `["must be 0.30000000000000004 maximum"]`)
+		assert.EqualError(t, ecto.Float().Test(floats.Max(1)).Process(lo.ToPtr(1.0000000000000009)), `["must be 1 maximum"]`)
+		assert.EqualError(t, ecto.Float().Test(floats.Max(100000000000000.1)).Process(lo.ToPtr(100000000000000.4)),
+			`["must be 100000000000000.1 maximum"]`)
+
+		numSchema := ecto.FloatFrom[json.Number]().Test(floats.Min(0.7), floats.Max(1.1))
+		assert.NoError(t, numSchema.Process(lo.ToPtr(json.Number("0.7"))))
+		assert.NoError(t, numSchema.Process(lo.ToPtr(json.Number("1.1"))))
+		assert.EqualError(t, numSchema.Process(lo.ToPtr(json.Number("0.69999999999999"))), `["must be 0.7 minimum"]`)
+		assert.EqualError(t, numSchema.Process(lo.ToPtr(json.Number("1.10000000000001"))), `["must be 1.1 maximum"]`)
+	})
+}
+
+func TestUUIDFrom(t *testing.T) {
+	schema := ecto.UUIDFrom[string]().Test(uuids.Version(4, 7), uuids.Variant(uuid.RFC4122))
+
+	assert.NoError(t, schema.Process(lo.ToPtr(uuid.NewString())))
+	assert.NoError(t, schema.Process(lo.ToPtr(uuid.Must(uuid.NewV7()).String())))
+	assert.EqualError(t, schema.Process(lo.ToPtr("a")), `["invalid UUID"]`)
+	assert.EqualError(t, schema.Process(lo.ToPtr(uuid.NewMD5(uuid.NameSpaceURL, nil).String())),
+		`["UUID version must be one of [4 7]"]`)
+
+	t.Run("array", func(t *testing.T) {
+		schema := ecto.UUIDFrom[[16]byte]().Test(uuids.NotNil())
+		assert.NoError(t, schema.Process(lo.ToPtr([16]byte(uuid.New()))))
+		assert.EqualError(t, schema.Process(&[16]byte{}), `["must not be nil UUID"]`)
+	})
+}
+
+func TestAtomicSchema_Transform(t *testing.T) {
+	schema := ecto.String().Transform(ectos.E164("US")).Test(ectos.PhoneCountry("US"))
+
+	v := "(415) 555-2671"
+	assert.NoError(t, schema.Process(&v))
+	assert.Equal(t, "+14155552671", v)
+	assert.EqualError(t, schema.Process(lo.ToPtr("+44 20 7946 0958")), `["phone number is not valid for US"]`)
+	assert.EqualError(t, schema.Process(lo.ToPtr("12")), `["invalid phone number format"]`)
+}
+
+func TestAddrFrom(t *testing.T) {
+	schema := ecto.AddrFrom[string]().Test(network.Public())
+
+	assert.NoError(t, schema.Process(lo.ToPtr("8.8.8.8")))
+	assert.EqualError(t, schema.Process(lo.ToPtr("10.0.0.1")), `["must be public IP address"]`)
+	assert.EqualError(t, schema.Process(lo.ToPtr("10.0.0")), `["invalid IP address"]`)
+
+	schema = ecto.AddrFrom[string]().Test(network.Is6())
+	assert.NoError(t, schema.Process(lo.ToPtr("2001:db8::1")))
+	assert.EqualError(t, schema.Process(lo.ToPtr("::ffff:8.8.8.8")), `["must be IPv6 address"]`)
+
+	t.Run("prefix", func(t *testing.T) {
+		schema := ecto.PrefixFrom[string]().Test(network.PrefixBits(16, 24))
+		assert.NoError(t, schema.Process(lo.ToPtr("10.0.0.0/16")))
+		assert.EqualError(t, schema.Process(lo.ToPtr("10.0.0.0/8")), `["prefix length must be between 16 and 24"]`)
+	})
+}
@@ -0,0 +1,14 @@
+// Command ectocheck reports ecto schema definitions that panic on construction (see ecto/ectocheck). Usage:
+//
+//	go install github.com/egsam98/ecto/cmd/ectocheck
+//	ectocheck ./...
+//	go vet -vettool=$(which ectocheck) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/egsam98/ecto/ectocheck"
+)
+
+func main() { singlechecker.Main(ectocheck.Analyzer) }
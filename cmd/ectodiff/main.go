@@ -0,0 +1,107 @@
+// Command ectodiff compares JSON snapshots of schemas (see ecto.Inspect) and exits with code 1 if changes break
+// clients of request or response schemas (see ecto/ectodiff). Snapshot is either a single node or an object of
+// named nodes, ex. {"CreateUser": {...}, "UpdateUser": {...}}. Usage:
+//
+//	ectodiff -usage request old.json new.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+
+	"github.com/samber/lo"
+
+	"github.com/egsam98/ecto"
+	"github.com/egsam98/ecto/ectodiff"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("ectodiff: ")
+
+	usage := flag.String("usage", string(ectodiff.Request), "schema usage: request or response")
+	all := flag.Bool("all", false, "print non-breaking changes too")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: ectodiff [flags] old.json new.json")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 || !slices.Contains([]ectodiff.Usage{ectodiff.Request, ectodiff.Response}, ectodiff.Usage(*usage)) {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	old, err := readSnapshot(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	new, err := readSnapshot(flag.Arg(1))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var breaking bool
+	for _, change := range compare(old, new) {
+		isBreaking := change.Breaking(ectodiff.Usage(*usage))
+		breaking = breaking || isBreaking
+		switch {
+		case isBreaking:
+			fmt.Println("BREAKING " + change.String())
+		case *all:
+			fmt.Println("         " + change.String())
+		}
+	}
+	if breaking {
+		os.Exit(1)
+	}
+}
+
+// readSnapshot decodes single node (named "") or object of named nodes
+func readSnapshot(path string) (map[string]ecto.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var node ecto.Node
+	if err := json.Unmarshal(data, &node); err == nil && node.Kind != "" {
+		return map[string]ecto.Node{"": node}, nil
+	}
+	var nodes map[string]ecto.Node
+	if err := json.Unmarshal(data, &nodes); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return nodes, nil
+}
+
+// compare prefixes paths of changes with schema names
+func compare(old, new map[string]ecto.Node) []ectodiff.Change {
+	var changes []ectodiff.Change
+	names := lo.Union(lo.Keys(old), lo.Keys(new))
+	slices.Sort(names)
+	for _, name := range names {
+		var prefix []string
+		if name != "" {
+			prefix = []string{name}
+		}
+
+		oldNode, inOld := old[name]
+		newNode, inNew := new[name]
+		switch {
+		case !inOld:
+			changes = append(changes, ectodiff.Change{Path: prefix, Effect: ectodiff.Compatible, Message: "schema added"})
+		case !inNew:
+			changes = append(changes, ectodiff.Change{Path: prefix, Effect: ectodiff.Incompatible, Message: "schema removed"})
+		default:
+			for _, change := range ectodiff.Compare(oldNode, newNode) {
+				change.Path = slices.Concat(prefix, change.Path)
+				changes = append(changes, change)
+			}
+		}
+	}
+	return changes
+}
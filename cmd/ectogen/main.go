@@ -0,0 +1,262 @@
+// Command ectogen generates type-safe builders of ecto.StructSchema for struct types, so typos in field names and
+// mismatches of field and schema types fail compilation instead of panicking at runtime. Usage:
+//
+//	//go:generate go run github.com/egsam98/ecto/cmd/ectogen -type Data,Address
+//
+// For struct Data it generates:
+//
+//	schema := DataSchema().
+//		Name(ecto.String().Required()).
+//		Address(AddressSchema().City(ecto.String()).Build()).
+//		Build() // ecto.StructSchema[Data]
+//
+// Flag -fast makes generated schemas access fields directly instead of reflection (see ecto.StructSchema.FieldPtrFunc).
+// Types declared in _test.go files produce _test.go output
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:embed schema.go.tmpl
+var tmplText string
+
+var tmpl = template.Must(template.New("schema").Parse(tmplText))
+
+// reservedMethods of generated builders can't be used as field names
+var reservedMethods = []string{"Build", "with"}
+
+type file struct {
+	Package string
+	// Imports are grouped into standard library and other ones
+	Imports [2][]string
+	Fast    bool
+	Types   []structType
+}
+
+type structType struct {
+	Name   string
+	Fields []field
+}
+
+type field struct {
+	Name string
+	Type string
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("ectogen: ")
+
+	typeNames := flag.String("type", "", "comma-separated list of struct type names; required")
+	output := flag.String("output", "", "output file name, relative to package directory unless absolute; default <type>_ecto.go")
+	fast := flag.Bool("fast", false, "generate reflection-free field access")
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+
+	out, err := generate(dir, strings.Split(*typeNames, ","), *fast)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	name := *output
+	if name == "" {
+		name = strings.ToLower((*typeNames)[:strings.IndexByte(*typeNames+",", ',')]) + "_ecto.go"
+		if out.test {
+			name = strings.TrimSuffix(name, ".go") + "_test.go"
+		}
+	}
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(dir, name)
+	}
+	if err := os.WriteFile(name, out.src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type result struct {
+	src  []byte
+	test bool
+}
+
+func generate(dir string, names []string, fast bool) (*result, error) {
+	fset := token.NewFileSet()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]*ast.File)
+	specs := make(map[string]*ast.TypeSpec)
+	for _, path := range paths {
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if ast.IsGenerated(f) {
+			continue
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				if slices.Contains(names, spec.Name.Name) {
+					found[spec.Name.Name] = f
+					specs[spec.Name.Name] = spec
+				}
+			}
+		}
+	}
+
+	var res result
+	out := file{Fast: fast}
+	imports := map[string]bool{`"github.com/egsam98/ecto"`: true, `"maps"`: true}
+	for i, name := range names {
+		f, ok := found[name]
+		if !ok {
+			return nil, fmt.Errorf("type %s not found in %s", name, dir)
+		}
+
+		test := strings.HasSuffix(fset.File(f.Pos()).Name(), "_test.go")
+		if i == 0 {
+			out.Package, res.test = f.Name.Name, test
+		} else if f.Name.Name != out.Package || test != res.test {
+			return nil, fmt.Errorf("types %s and %s are declared in different packages", names[0], name)
+		}
+
+		typ, err := parseStruct(fset, f, specs[name], imports)
+		if err != nil {
+			return nil, err
+		}
+		out.Types = append(out.Types, *typ)
+	}
+	for imp := range imports {
+		group := 0
+		if path, _ := strconv.Unquote(imp[strings.IndexByte(imp, '"'):]); strings.Contains(strings.Split(path, "/")[0], ".") {
+			group = 1
+		}
+		out.Imports[group] = append(out.Imports[group], imp)
+	}
+	slices.Sort(out.Imports[0])
+	slices.Sort(out.Imports[1])
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, out); err != nil {
+		return nil, err
+	}
+	res.src, err = format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w\n%s", err, buf.Bytes())
+	}
+	return &res, nil
+}
+
+// parseStruct collects exported fields and imports used by their types
+func parseStruct(fset *token.FileSet, f *ast.File, spec *ast.TypeSpec, imports map[string]bool) (*structType, error) {
+	name := spec.Name.Name
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a struct", name)
+	}
+	if spec.TypeParams != nil {
+		return nil, fmt.Errorf("%s: generic types aren't supported", name)
+	}
+
+	typ := structType{Name: name}
+	for _, astField := range st.Fields.List {
+		var typeText bytes.Buffer
+		if err := format.Node(&typeText, fset, astField.Type); err != nil {
+			return nil, err
+		}
+
+		names := astField.Names
+		if len(names) == 0 {
+			// Embedded field is named after its type
+			ident := astField.Type
+			if star, ok := ident.(*ast.StarExpr); ok {
+				ident = star.X
+			}
+			if sel, ok := ident.(*ast.SelectorExpr); ok {
+				ident = sel.Sel
+			}
+			embedded, ok := ident.(*ast.Ident)
+			if !ok {
+				return nil, fmt.Errorf("%s: embedded field of type %s isn't supported", name, typeText.String())
+			}
+			names = []*ast.Ident{embedded}
+		}
+
+		for _, ident := range names {
+			if !ident.IsExported() {
+				continue
+			}
+			if slices.Contains(reservedMethods, ident.Name) {
+				return nil, fmt.Errorf("%s.%s: field name conflicts with builder method", name, ident.Name)
+			}
+			typ.Fields = append(typ.Fields, field{Name: ident.Name, Type: typeText.String()})
+		}
+
+		ast.Inspect(astField.Type, func(node ast.Node) bool {
+			sel, ok := node.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); ok {
+				if imp := findImport(f, pkg.Name); imp != "" {
+					imports[imp] = true
+				}
+			}
+			return false
+		})
+	}
+	return &typ, nil
+}
+
+// findImport returns import spec of package referenced by name in the file
+func findImport(f *ast.File, name string) string {
+	for _, imp := range f.Imports {
+		path := strings.Trim(imp.Path.Value, `"`)
+		if imp.Name != nil {
+			if imp.Name.Name == name {
+				return imp.Name.Name + " " + imp.Path.Value
+			}
+			continue
+		}
+		// Last path element except for major version is assumed to be the package name,
+		// ex. "github.com/google/uuid", "gopkg.in/yaml.v3", "github.com/jackc/pgx/v5"
+		elems := strings.Split(path, "/")
+		last := elems[len(elems)-1]
+		if len(elems) > 1 && strings.HasPrefix(last, "v") && strings.Trim(last[1:], "0123456789") == "" {
+			last = elems[len(elems)-2]
+		}
+		if last, _, _ = strings.Cut(last, ".v"); last == name {
+			return imp.Path.Value
+		}
+	}
+	return ""
+}
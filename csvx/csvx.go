@@ -0,0 +1,229 @@
+// Package csvx imports CSV files into structs validated by ecto.StructSchema row by row, so files of any size are
+// processed in constant memory. Columns are mapped to fields by headers equal to `csv` tags or field names, cells
+// are parsed into strings, booleans, numbers, time.Duration, encoding.TextUnmarshaler implementations (time.Time,
+// uuid.UUID, decimal.Decimal etc.) and pointers to them. Empty cells leave zero values. Unknown columns are ignored:
+//
+//	report, err := csvx.Import(file, orderSchema, func(order Order) error {
+//		return repo.Save(ctx, order)
+//	}, csvx.MaxInvalid(100), csvx.OnIssue(func(issue csvx.Issue) {
+//		log.Printf("row %d, %s: %s", issue.Row, issue.Column, issue.Errors)
+//	}))
+package csvx
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/egsam98/errors"
+
+	"github.com/egsam98/ecto"
+	"github.com/egsam98/ecto/internal/text"
+)
+
+// ErrAborted is returned by Import if number of invalid rows reaches limit (see MaxInvalid)
+var ErrAborted = errors.New("too many invalid rows")
+
+type Opt func(*config)
+
+// Tag sets key of struct tags naming columns ("csv" by default)
+func Tag(key string) Opt {
+	return func(cfg *config) { cfg.tag = key }
+}
+
+// Comma sets separator of cells ("," by default)
+func Comma(comma rune) Opt {
+	return func(cfg *config) { cfg.comma = comma }
+}
+
+// MaxInvalid aborts import after n invalid rows, the first one by default. Zero n means SkipInvalid
+func MaxInvalid(n uint) Opt {
+	return func(cfg *config) { cfg.maxInvalid = int(n) }
+}
+
+// SkipInvalid imports valid rows regardless of number of invalid ones
+func SkipInvalid() Opt { return MaxInvalid(0) }
+
+// OnIssue streams issues of invalid rows
+func OnIssue(fn func(Issue)) Opt {
+	return func(cfg *config) { cfg.onIssue = fn }
+}
+
+// Context passes ctx to schema processing rows
+func Context(ctx context.Context) Opt {
+	return func(cfg *config) { cfg.ctx = ctx }
+}
+
+type config struct {
+	tag        string
+	comma      rune
+	maxInvalid int
+	onIssue    func(Issue)
+	ctx        context.Context
+}
+
+// Issue lists errors of cell or entire row
+type Issue struct {
+	// Row is a line of record in file, header is the 1st one
+	Row int
+	// Column is a header of invalid cell. It's empty for malformed row
+	Column string
+	Errors ecto.ListError
+}
+
+// Report counts imported rows excluding header
+type Report struct {
+	Rows, Valid, Invalid int
+}
+
+// Import reads CSV with header from r, passing valid rows to fn. Invalid ones are skipped and reported via OnIssue
+// until MaxInvalid is reached. Errors of reading and fn abort import
+func Import[T any](r io.Reader, schema ecto.StructSchema[T], fn func(value T) error, opts ...Opt) (Report, error) {
+	cfg := config{tag: "csv", comma: ',', maxInvalid: 1, onIssue: func(Issue) {}, ctx: context.Background()}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = cfg.comma
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	var report Report
+	header, err := reader.Read()
+	if err == io.EOF {
+		return report, nil
+	}
+	if err != nil {
+		return report, errors.Wrap(err, "read header")
+	}
+	header = slices.Clone(header)
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	imp, err := newImporter[T](header, schema, cfg)
+	if err != nil {
+		return report, err
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return report, nil
+		}
+
+		var issues []Issue
+		var row int
+		var value T
+		switch err := err.(type) {
+		case nil:
+			row, _ = reader.FieldPos(0)
+			issues = imp.row(row, record, &value)
+		case *csv.ParseError:
+			row = err.StartLine
+			issues = []Issue{{Row: row, Errors: ecto.ListError{ecto.Error(err.Err.Error())}}}
+		default:
+			return report, errors.Wrap(err, "read row")
+		}
+
+		report.Rows++
+		if len(issues) == 0 {
+			report.Valid++
+			if err := fn(value); err != nil {
+				return report, errors.Wrapf(err, "row %d", row)
+			}
+			continue
+		}
+
+		report.Invalid++
+		for _, issue := range issues {
+			cfg.onIssue(issue)
+		}
+		if cfg.maxInvalid > 0 && report.Invalid >= cfg.maxInvalid {
+			return report, errors.Wrapf(ErrAborted, "row %d", row)
+		}
+	}
+}
+
+type importer[T any] struct {
+	cfg    config
+	schema ecto.StructSchema[T]
+	header []string
+	// fields by indexes of columns, nil for unknown ones
+	fields []*reflect.StructField
+	// columns by struct tags of processing errors (see ecto.FieldMeta)
+	columns map[string]string
+}
+
+func newImporter[T any](header []string, schema ecto.StructSchema[T], cfg config) (*importer[T], error) {
+	imp := importer[T]{
+		cfg:     cfg,
+		schema:  schema,
+		header:  header,
+		fields:  make([]*reflect.StructField, len(header)),
+		columns: make(map[string]string),
+	}
+
+	typ := reflect.TypeFor[T]()
+	meta := schema.Meta()
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get(cfg.tag), ",")
+		name = cmp.Or(name, field.Name)
+		column := slices.Index(header, name)
+		if !field.IsExported() || name == "-" || column < 0 {
+			continue
+		}
+		if !text.Parsable(field.Type) && !(field.Type.Kind() == reflect.Pointer && text.Parsable(field.Type.Elem())) {
+			return nil, errors.Errorf("column %s: unsupported type %s of %s.%s", name, field.Type, typ, field.Name)
+		}
+		imp.fields[column] = &field
+		imp.columns[cmp.Or(meta[field.Name].Tag, field.Name)] = name
+	}
+	return &imp, nil
+}
+
+// row parses record into value and processes it
+func (imp *importer[T]) row(row int, record []string, value *T) []Issue {
+	if len(record) != len(imp.header) {
+		return []Issue{{Row: row, Errors: ecto.ListError{
+			ecto.Errorf("expected %d columns, got %d", len(imp.header), len(record)),
+		}}}
+	}
+
+	var issues []Issue
+	rv := reflect.ValueOf(value).Elem()
+	for i, field := range imp.fields {
+		if field == nil || record[i] == "" {
+			continue
+		}
+		if err := text.Parse(rv.FieldByIndex(field.Index), record[i]); err != nil {
+			issues = append(issues, Issue{Row: row, Column: imp.header[i], Errors: ecto.ListError{*err}})
+		}
+	}
+	if len(issues) > 0 {
+		return issues
+	}
+
+	mapErr, _ := imp.schema.ProcessContext(imp.cfg.ctx, value).(ecto.MapError)
+	for key, err := range mapErr {
+		issues = append(issues, Issue{Row: row, Column: cmp.Or(imp.columns[key], key), Errors: text.Flatten(err)})
+	}
+	slices.SortFunc(issues, func(a, b Issue) int {
+		return cmp.Or(cmp.Compare(imp.columnIndex(a.Column), imp.columnIndex(b.Column)), strings.Compare(a.Column, b.Column))
+	})
+	return issues
+}
+
+// columnIndex orders issues by columns, ones of fields without columns are the last
+func (imp *importer[T]) columnIndex(column string) int {
+	if i := slices.Index(imp.header, column); i >= 0 {
+		return i
+	}
+	return len(imp.header)
+}
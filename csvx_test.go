@@ -0,0 +1,108 @@
+package ecto_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egsam98/ecto"
+	"github.com/egsam98/ecto/csvx"
+	"github.com/egsam98/ecto/decimals"
+	integer "github.com/egsam98/ecto/ints"
+	ectos "github.com/egsam98/ecto/strings"
+)
+
+type CSVProduct struct {
+	SKU      string          `json:"sku" csv:"sku"`
+	Quantity int             `json:"qty" csv:"quantity"`
+	Price    decimal.Decimal `csv:"price"`
+	Note     *string
+}
+
+var csvProductSchema = ecto.Struct[CSVProduct](ecto.M{
+	"SKU":      ecto.String().Required().Test(ectos.Min(3)),
+	"Quantity": ecto.Int().Test(integer.Min(1)),
+	"Price":    ecto.Atomic[decimal.Decimal]().Required().Test(decimals.Positive()),
+})
+
+const csvProducts = "\ufeffsku,quantity,price,Note,extra\n" +
+	"abc,2,9.99,,x\n" +
+	"ab,0,1,,x\n" +
+	"def,x,1,note,x\n" +
+	"\"g\"h,1,1,,x\n" +
+	"ghi,1\n" +
+	"jkl,3,0.5,fragile,x\n"
+
+func TestCSVImport(t *testing.T) {
+	var products []CSVProduct
+	var issues []csvx.Issue
+	report, err := csvx.Import(strings.NewReader(csvProducts), csvProductSchema, func(product CSVProduct) error {
+		products = append(products, product)
+		return nil
+	}, csvx.SkipInvalid(), csvx.OnIssue(func(issue csvx.Issue) { issues = append(issues, issue) }))
+	require.NoError(t, err)
+
+	assert.Equal(t, csvx.Report{Rows: 6, Valid: 2, Invalid: 4}, report)
+	require.Len(t, products, 2)
+	assert.Equal(t, "abc", products[0].SKU)
+	assert.Nil(t, products[0].Note)
+	assert.Equal(t, "fragile", *products[1].Note)
+	assert.True(t, decimal.RequireFromString("0.5").Equal(products[1].Price))
+	assert.Equal(t, []csvx.Issue{
+		{Row: 3, Column: "sku", Errors: ecto.ListError{"must be at least 3 characters long"}},
+		{Row: 3, Column: "quantity", Errors: ecto.ListError{"must be 1 minimum"}},
+		{Row: 4, Column: "quantity", Errors: ecto.ListError{`invalid int "x"`}},
+		{Row: 5, Errors: ecto.ListError{`extraneous or missing " in quoted-field`}},
+		{Row: 6, Errors: ecto.ListError{"expected 5 columns, got 2"}},
+	}, issues)
+
+	t.Run("abort", func(t *testing.T) {
+		report, err := csvx.Import(strings.NewReader(csvProducts), csvProductSchema,
+			func(CSVProduct) error { return nil }, csvx.MaxInvalid(2))
+		assert.ErrorIs(t, err, csvx.ErrAborted)
+		assert.ErrorContains(t, err, "row 4")
+		assert.Equal(t, csvx.Report{Rows: 3, Valid: 1, Invalid: 2}, report)
+
+		_, err = csvx.Import(strings.NewReader(csvProducts), csvProductSchema, func(CSVProduct) error { return nil })
+		assert.ErrorContains(t, err, "row 3")
+	})
+
+	t.Run("callback", func(t *testing.T) {
+		errStop := errors.New("stop")
+		_, err := csvx.Import(strings.NewReader(csvProducts), csvProductSchema,
+			func(CSVProduct) error { return errStop })
+		assert.ErrorIs(t, err, errStop)
+	})
+
+	t.Run("semicolon", func(t *testing.T) {
+		report, err := csvx.Import(strings.NewReader("sku;quantity;price\nabc;1;1\n"), csvProductSchema,
+			func(CSVProduct) error { return nil }, csvx.Comma(';'))
+		require.NoError(t, err)
+		assert.Equal(t, csvx.Report{Rows: 1, Valid: 1}, report)
+	})
+
+	t.Run("zero-padded", func(t *testing.T) {
+		type Row struct {
+			Zip int `csv:"zip"`
+		}
+		var rows []Row
+		_, err := csvx.Import(strings.NewReader("zip\n0123\n08080\n"), ecto.Struct[Row](nil), func(row Row) error {
+			rows = append(rows, row)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []Row{{Zip: 123}, {Zip: 8080}}, rows)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		type Row struct {
+			Tags []string `csv:"tags"`
+		}
+		_, err := csvx.Import(strings.NewReader("tags\na\n"), ecto.Struct[Row](nil), func(Row) error { return nil })
+		assert.ErrorContains(t, err, "column tags: unsupported type []string")
+	})
+}
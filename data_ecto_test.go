@@ -0,0 +1,154 @@
+// Code generated by ectogen; DO NOT EDIT.
+
+package ecto_test
+
+import (
+	"maps"
+
+	"github.com/egsam98/ecto"
+	"github.com/google/uuid"
+)
+
+// DataBuilder is a type-safe builder of ecto.StructSchema[Data]
+type DataBuilder struct {
+	fields ecto.M
+}
+
+// DataSchema starts DataBuilder without field schemas
+func DataSchema() DataBuilder { return DataBuilder{fields: ecto.M{}} }
+
+// A sets schema of field A
+func (b DataBuilder) A(schema ecto.TypedSchema[Hello]) DataBuilder {
+	return b.with("A", schema)
+}
+
+// B sets schema of field B
+func (b DataBuilder) B(schema ecto.TypedSchema[string]) DataBuilder {
+	return b.with("B", schema)
+}
+
+// C sets schema of field C
+func (b DataBuilder) C(schema ecto.TypedSchema[C]) DataBuilder {
+	return b.with("C", schema)
+}
+
+// D sets schema of field D
+func (b DataBuilder) D(schema ecto.TypedSchema[[]*string]) DataBuilder {
+	return b.with("D", schema)
+}
+
+// E sets schema of field E
+func (b DataBuilder) E(schema ecto.TypedSchema[uuid.UUID]) DataBuilder {
+	return b.with("E", schema)
+}
+
+// F sets schema of field F
+func (b DataBuilder) F(schema ecto.TypedSchema[[]F]) DataBuilder {
+	return b.with("F", schema)
+}
+
+// G sets schema of field G
+func (b DataBuilder) G(schema ecto.TypedSchema[*int]) DataBuilder {
+	return b.with("G", schema)
+}
+
+// Build returns ecto.StructSchema[Data]
+func (b DataBuilder) Build() ecto.StructSchema[Data] {
+	return ecto.Struct[Data](b.fields).FieldPtrFunc(DataFieldPtr)
+}
+
+func (b DataBuilder) with(key string, schema ecto.Schema) DataBuilder {
+	b.fields = maps.Clone(b.fields)
+	b.fields[key] = schema
+	return b
+}
+
+// DataFieldPtr returns pointer to field of Data by its name (see ecto.StructSchema.FieldPtrFunc)
+func DataFieldPtr(ptr *Data, key string) any {
+	switch key {
+	case "A":
+		return &ptr.A
+	case "B":
+		return &ptr.B
+	case "C":
+		return &ptr.C
+	case "D":
+		return &ptr.D
+	case "E":
+		return &ptr.E
+	case "F":
+		return &ptr.F
+	case "G":
+		return &ptr.G
+	default:
+		return nil
+	}
+}
+
+// CBuilder is a type-safe builder of ecto.StructSchema[C]
+type CBuilder struct {
+	fields ecto.M
+}
+
+// CSchema starts CBuilder without field schemas
+func CSchema() CBuilder { return CBuilder{fields: ecto.M{}} }
+
+// C1 sets schema of field C1
+func (b CBuilder) C1(schema ecto.TypedSchema[string]) CBuilder {
+	return b.with("C1", schema)
+}
+
+// Build returns ecto.StructSchema[C]
+func (b CBuilder) Build() ecto.StructSchema[C] {
+	return ecto.Struct[C](b.fields).FieldPtrFunc(CFieldPtr)
+}
+
+func (b CBuilder) with(key string, schema ecto.Schema) CBuilder {
+	b.fields = maps.Clone(b.fields)
+	b.fields[key] = schema
+	return b
+}
+
+// CFieldPtr returns pointer to field of C by its name (see ecto.StructSchema.FieldPtrFunc)
+func CFieldPtr(ptr *C, key string) any {
+	switch key {
+	case "C1":
+		return &ptr.C1
+	default:
+		return nil
+	}
+}
+
+// FBuilder is a type-safe builder of ecto.StructSchema[F]
+type FBuilder struct {
+	fields ecto.M
+}
+
+// FSchema starts FBuilder without field schemas
+func FSchema() FBuilder { return FBuilder{fields: ecto.M{}} }
+
+// F1 sets schema of field F1
+func (b FBuilder) F1(schema ecto.TypedSchema[string]) FBuilder {
+	return b.with("F1", schema)
+}
+
+// Build returns ecto.StructSchema[F]
+func (b FBuilder) Build() ecto.StructSchema[F] {
+	return ecto.Struct[F](b.fields).FieldPtrFunc(FFieldPtr)
+}
+
+func (b FBuilder) with(key string, schema ecto.Schema) FBuilder {
+	b.fields = maps.Clone(b.fields)
+	b.fields[key] = schema
+	return b
+}
+
+// FFieldPtr returns pointer to field of F by its name (see ecto.StructSchema.FieldPtrFunc)
+func FFieldPtr(ptr *F, key string) any {
+	switch key {
+	case "F1":
+		return &ptr.F1
+	default:
+		return nil
+	}
+}
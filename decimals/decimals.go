@@ -0,0 +1,124 @@
+package decimals
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/egsam98/errors"
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/currency"
+
+	"github.com/egsam98/ecto"
+)
+
+// Min restricts value with lower inclusive bound
+func Min(value decimal.Decimal) ecto.Test[decimal.Decimal] {
+	return ecto.Test[decimal.Decimal]{
+		Code:   "decimals.Min",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Errorf("must be %s minimum", value),
+		Func:   func(v *decimal.Decimal) bool { return v.GreaterThanOrEqual(value) },
+	}
+}
+
+// Max restricts value with upper inclusive bound
+func Max(value decimal.Decimal) ecto.Test[decimal.Decimal] {
+	return ecto.Test[decimal.Decimal]{
+		Code:   "decimals.Max",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Errorf("must be %s maximum", value),
+		Func:   func(v *decimal.Decimal) bool { return v.LessThanOrEqual(value) },
+	}
+}
+
+// Positive forces value to be greater than zero
+func Positive() ecto.Test[decimal.Decimal] {
+	return ecto.Test[decimal.Decimal]{
+		Code:  "decimals.Positive",
+		Error: "must be positive",
+		Func:  func(v *decimal.Decimal) bool { return v.IsPositive() },
+	}
+}
+
+// OneOf restricts value to limited variants. Unlike ecto.OneOf variants are compared numerically,
+// i.e. "1.0" matches 1
+func OneOf(variants ...decimal.Decimal) ecto.Test[decimal.Decimal] {
+	return ecto.Test[decimal.Decimal]{
+		Code:   "decimals.OneOf",
+		Params: map[string]any{"variants": variants},
+		Error:  ecto.Errorf("must be one of %v", variants),
+		Func: func(v *decimal.Decimal) bool {
+			return slices.ContainsFunc(variants, func(variant decimal.Decimal) bool { return v.Equal(variant) })
+		},
+	}
+}
+
+// MaxScale restricts number of fractional digits. Trailing zeros are ignored, i.e. "1.50" has scale 1
+func MaxScale(value uint) ecto.Test[decimal.Decimal] {
+	return ecto.Test[decimal.Decimal]{
+		Code:   "decimals.MaxScale",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Errorf("must have at most %d decimal places", value),
+		Func:   func(v *decimal.Decimal) bool { return scale(*v) <= value },
+	}
+}
+
+// MaxPrecision restricts total number of significant digits (integer and fractional ones), similar to
+// precision of SQL NUMERIC type
+func MaxPrecision(value uint) ecto.Test[decimal.Decimal] {
+	return ecto.Test[decimal.Decimal]{
+		Code:   "decimals.MaxPrecision",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Errorf("must have at most %d digits", value),
+		Func:   func(v *decimal.Decimal) bool { return integerDigits(*v)+scale(*v) <= value },
+	}
+}
+
+// MaxIntegerDigits restricts number of digits before decimal point
+func MaxIntegerDigits(value uint) ecto.Test[decimal.Decimal] {
+	return ecto.Test[decimal.Decimal]{
+		Code:   "decimals.MaxIntegerDigits",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Errorf("must have at most %d integer digits", value),
+		Func:   func(v *decimal.Decimal) bool { return integerDigits(*v) <= value },
+	}
+}
+
+// MultipleOf forces value to be divisible by step without remainder (ex. 0.05 for cash amounts)
+func MultipleOf(step decimal.Decimal) ecto.Test[decimal.Decimal] {
+	if step.IsZero() {
+		panic(errors.New("step must not be zero"))
+	}
+	return ecto.Test[decimal.Decimal]{
+		Code:   "decimals.MultipleOf",
+		Params: map[string]any{"step": step},
+		Error:  ecto.Errorf("must be a multiple of %s", step),
+		Func:   func(v *decimal.Decimal) bool { return v.Mod(step).IsZero() },
+	}
+}
+
+// MinorUnits restricts number of fractional digits according to ISO-4217 minor units of currency
+// (ex. 2 for USD, 0 for JPY). Panics if currency code is invalid
+func MinorUnits(code string) ecto.Test[decimal.Decimal] {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		panic(errors.Wrapf(err, "parse currency %q", code))
+	}
+	units, _ := currency.Standard.Rounding(unit)
+	return ecto.Test[decimal.Decimal]{
+		Code:   "decimals.MinorUnits",
+		Params: map[string]any{"code": code},
+		Error:  ecto.Errorf("must have at most %d decimal places for %s", units, unit),
+		Func:   func(v *decimal.Decimal) bool { return scale(*v) <= uint(units) },
+	}
+}
+
+func scale(d decimal.Decimal) uint {
+	_, frac, _ := strings.Cut(d.String(), ".")
+	return uint(len(frac))
+}
+
+func integerDigits(d decimal.Decimal) uint {
+	integer, _, _ := strings.Cut(d.Abs().String(), ".")
+	return uint(len(strings.TrimLeft(integer, "0")))
+}
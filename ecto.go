@@ -1,6 +1,7 @@
 package ecto
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/egsam98/errors"
@@ -10,7 +11,15 @@ import (
 // Schema common interface to process input data (conversions, validations etc.)
 type Schema interface {
 	ForType() reflect.Type
-	process(ptr any) error
+	process(ctx context.Context, ptr any) error
+	describe(d *describer) Node
+}
+
+// TypedSchema is a Schema for values of type T known at compile time, so mismatch of schema and field types
+// doesn't compile (see cmd/ectogen)
+type TypedSchema[T any] interface {
+	Schema
+	typed(T)
 }
 
 type IAtomicOrPtrSchema interface {
@@ -33,26 +42,63 @@ type IStructSchema interface {
 	Meta() map[string]FieldMeta
 }
 
-// Test holds predicate function to apply on validated data and returns Error in case of failure
+type ILazySchema interface {
+	Schema
+	Resolve() Schema
+}
+
+// Test holds predicate function to apply on validated data and returns Error in case of failure.
+// FuncContext is used instead of Func if set, it receives context of processing (see Parent).
+// ErrorFunc replaces Error of failed value if set, ex. to tell malformed value from invalid one.
+// CheckParent validates type of struct passed to FuncContext (see Parent) once on construction of StructSchema.
+// Code and Params describe the test for introspection (see Inspect), ex. "strings.Min" and {"length": 3},
+// they're empty for custom tests
 type Test[T any] struct {
-	Error Error
-	Func  func(v *T) bool
+	Code        string
+	Params      map[string]any
+	Error       Error
+	Func        func(v *T) bool
+	FuncContext func(ctx context.Context, v *T) bool
+	ErrorFunc   func(v *T) Error
+	CheckParent func(typ reflect.Type) error
 }
 
 // Run applies predicate
-func (t *Test[T]) Run(ptr *T) *Error {
-	if t.Func(ptr) {
+func (t *Test[T]) Run(ptr *T) *Error { return t.RunContext(context.Background(), ptr) }
+
+// RunContext applies predicate with context of processing
+func (t *Test[T]) RunContext(ctx context.Context, ptr *T) *Error {
+	var ok bool
+	if t.FuncContext != nil {
+		ok = t.FuncContext(ctx, ptr)
+	} else {
+		ok = t.Func(ptr)
+	}
+	if ok {
 		return nil
 	}
+	if t.ErrorFunc != nil {
+		err := t.ErrorFunc(ptr)
+		return &err
+	}
 	return &t.Error
 }
 
+// Parent returns pointer to the innermost struct processed by StructSchema, so tests are able to compare
+// a field with its siblings (see Test.FuncContext). Returns nil outside StructSchema or if none of tests of
+// its fields has FuncContext
+func Parent(ctx context.Context) any { return ctx.Value(parentKey{}) }
+
+type parentKey struct{}
+
 // OneOf restricts value to limited variants
 func OneOf[T comparable](variants ...T) Test[T] {
 	set := lo.Keyify(variants)
 	return Test[T]{
-		Error: Errorf("must be one of %v", variants),
-		Func:  func(v *T) bool { return lo.HasKey(set, *v) },
+		Code:   "ecto.OneOf",
+		Params: map[string]any{"variants": variants},
+		Error:  Errorf("must be one of %v", variants),
+		Func:   func(v *T) bool { return lo.HasKey(set, *v) },
 	}
 }
 
@@ -0,0 +1,196 @@
+// Package ectocheck defines an analyzer reporting ecto schema definitions that panic on construction:
+//   - unknown keys of ecto.M passed to ecto.Struct and StructSchema.Extend or ones naming unexported fields
+//   - schemas of struct fields, slice elements (ecto.Slice) and pointees (ecto.Ptr) built for another type
+//
+// It also flags deprecated AtomicSchema.OmitZero. Only schemas of known types are checked, i.e. ones
+// stored in ecto.Schema variables are skipped
+package ectocheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const ectoPath = "github.com/egsam98/ecto"
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "ectocheck",
+	Doc:      "check ecto schema definitions for unknown struct fields and mismatched types",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		call := node.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != ectoPath {
+			return
+		}
+
+		switch recv := receiverName(fn); {
+		case recv == "" && fn.Name() == "Struct", recv == "StructSchema" && fn.Name() == "Extend":
+			checkStruct(pass, call)
+		case recv == "" && (fn.Name() == "Slice" || fn.Name() == "Ptr"):
+			checkInner(pass, call)
+		case recv == "AtomicSchema" && fn.Name() == "OmitZero":
+			pass.Reportf(call.Pos(), "OmitZero is deprecated: use ecto.Ptr for optional values")
+		}
+	})
+	return nil, nil
+}
+
+// checkStruct checks keys and schemas of ecto.M literal against fields of struct type argument
+func checkStruct(pass *analysis.Pass, call *ast.CallExpr) {
+	typ := typeArg(pass.TypesInfo.TypeOf(call), 0)
+	if typ == nil || len(call.Args) != 1 {
+		return
+	}
+	if _, ok := typ.(*types.TypeParam); ok {
+		return
+	}
+	st, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		pass.Reportf(call.Pos(), "%s is not a struct", typeString(pass, typ))
+		return
+	}
+
+	lit, ok := ast.Unparen(call.Args[0]).(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key := pass.TypesInfo.Types[kv.Key].Value
+		if key == nil || key.Kind() != constant.String {
+			continue
+		}
+
+		name := constant.StringVal(key)
+		field := directField(st, name)
+		if field == nil {
+			pass.Reportf(kv.Key.Pos(), "%s has no field %s", typeString(pass, typ), name)
+			continue
+		}
+		if !field.Exported() {
+			pass.Reportf(kv.Key.Pos(), "field %s.%s is unexported", typeString(pass, typ), name)
+			continue
+		}
+		if schemaType := forType(pass.TypesInfo.TypeOf(kv.Value)); schemaType != nil && !types.Identical(schemaType, field.Type()) {
+			pass.Reportf(kv.Value.Pos(), "schema of %s.%s is for %s, but field has type %s%s",
+				typeString(pass, typ), name, typeString(pass, schemaType), typeString(pass, field.Type()),
+				suggest(pass, field.Type(), schemaType))
+		}
+	}
+}
+
+// checkInner checks inner schema of ecto.Slice and ecto.Ptr against element type
+func checkInner(pass *analysis.Pass, call *ast.CallExpr) {
+	result := pass.TypesInfo.TypeOf(call)
+	if len(call.Args) != 1 {
+		return
+	}
+
+	var want types.Type
+	switch name := schemaName(result); name {
+	case "SliceSchema":
+		want = typeArg(result, 1)
+	case "PtrSchema":
+		want = typeArg(result, 0)
+	}
+	schemaType := forType(pass.TypesInfo.TypeOf(call.Args[0]))
+	if want == nil || schemaType == nil || types.Identical(want, schemaType) {
+		return
+	}
+	pass.Reportf(call.Args[0].Pos(), "inner schema is for %s, want %s%s",
+		typeString(pass, schemaType), typeString(pass, want), suggest(pass, want, schemaType))
+}
+
+// forType returns type processed by schema of type t (see ecto.Schema.ForType) or nil if it's unknown statically
+func forType(t types.Type) types.Type {
+	switch schemaName(t) {
+	case "AtomicSchema", "StructSchema", "SliceSchema", "LazySchema":
+		return typeArg(t, 0)
+	case "PtrSchema":
+		if to := typeArg(t, 0); to != nil {
+			return types.NewPointer(to)
+		}
+	}
+	return nil
+}
+
+// suggest recommends shorthand converting field type into type of schema
+func suggest(pass *analysis.Pass, field, schema types.Type) string {
+	basic, _ := field.Underlying().(*types.Basic)
+	var shorthand string
+	switch schemaType := types.TypeString(schema, nil); {
+	case basic == nil:
+	case schemaType == "int" && basic.Info()&types.IsInteger != 0:
+		shorthand = "IntFrom"
+	case schemaType == "string" && basic.Info()&types.IsString != 0:
+		shorthand = "StringFrom"
+	case schemaType == "float64" && basic.Info()&(types.IsFloat|types.IsString) != 0:
+		shorthand = "FloatFrom"
+	case schemaType == "github.com/shopspring/decimal.Decimal" && basic.Info()&(types.IsFloat|types.IsString) != 0:
+		shorthand = "DecimalFrom"
+	case schemaType == "time.Time" && basic.Info()&types.IsString != 0:
+		shorthand = "TimeFrom"
+	}
+	if shorthand == "" {
+		return ""
+	}
+	return "; use ecto." + shorthand + "[" + typeString(pass, field) + "]()"
+}
+
+func directField(st *types.Struct, name string) *types.Var {
+	for i := range st.NumFields() {
+		if field := st.Field(i); field.Name() == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// receiverName returns name of ecto type the method is declared on or "" for functions
+func receiverName(fn *types.Func) string {
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return ""
+	}
+	t := recv.Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	return schemaName(t)
+}
+
+// schemaName returns name of generic ecto type, ex. "StructSchema"
+func schemaName(t types.Type) string {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != ectoPath {
+		return ""
+	}
+	return named.Obj().Name()
+}
+
+func typeArg(t types.Type, i int) types.Type {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.TypeArgs().Len() <= i {
+		return nil
+	}
+	return named.TypeArgs().At(i)
+}
+
+func typeString(pass *analysis.Pass, t types.Type) string {
+	return types.TypeString(t, types.RelativeTo(pass.Pkg))
+}
@@ -0,0 +1,13 @@
+package ectocheck_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/egsam98/ecto/ectocheck"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), ectocheck.Analyzer, "a")
+}
@@ -0,0 +1,50 @@
+package a
+
+import "github.com/egsam98/ecto"
+
+type Role string
+
+type User struct {
+	Name    string
+	Age     int64
+	Role    Role
+	Tags    []string
+	Manager *User
+	Friends []User
+	email   string
+}
+
+func schemas() {
+	var user ecto.StructSchema[User]
+	user = ecto.Struct[User](ecto.M{
+		"Name":    ecto.String(),
+		"Nmae":    ecto.String(),                    // want `User has no field Nmae`
+		"email":   ecto.String(),                    // want `field User.email is unexported`
+		"Age":     ecto.Int(),                       // want `schema of User.Age is for int, but field has type int64; use ecto.IntFrom\[int64\]\(\)`
+		"Role":    ecto.Int(),                       // want `schema of User.Role is for int, but field has type Role$`
+		"Tags":    ecto.Slice[[]string](ecto.Int()), // want `inner schema is for int, want string$`
+		"Manager": ecto.Ptr[User](ecto.Lazy[User](func() ecto.Schema { return user })),
+		"Friends": ecto.Slice[[]User](ecto.Lazy[Role](nil)), // want `inner schema is for Role, want User$`
+	})
+
+	_ = user.Extend(ecto.M{
+		"Role":    ecto.StringFrom[Role](),
+		"Manager": ecto.Ptr[Role](ecto.String()), // want `schema of User.Manager is for \*Role, but field has type \*User` `inner schema is for string, want Role; use ecto.StringFrom\[Role\]\(\)`
+		"Unknown": ecto.String(),                 // want `User has no field Unknown`
+	})
+}
+
+var _ = ecto.Struct[int](ecto.M{}) // want `int is not a struct`
+
+var _ = ecto.String().OmitZero() // want `OmitZero is deprecated: use ecto.Ptr for optional values`
+
+// Schemas of unknown types aren't checked
+var (
+	schema ecto.Schema = ecto.Int()
+	_                  = ecto.Struct[User](ecto.M{"Name": schema})
+	_                  = ecto.Slice[[]string](schema)
+)
+
+func generic[T any]() ecto.StructSchema[T] {
+	return ecto.Struct[T](ecto.M{"Name": ecto.String()})
+}
@@ -0,0 +1,38 @@
+// Package ecto stubs schema constructors checked by ectocheck
+package ecto
+
+type Schema interface{}
+
+type M = map[string]Schema
+
+type AtomicSchema[T comparable, R any] struct{}
+
+func Int() AtomicSchema[int, int] { return AtomicSchema[int, int]{} }
+
+func IntFrom[T ~int | ~int8 | ~int16 | ~int32 | ~int64]() AtomicSchema[T, int] {
+	return AtomicSchema[T, int]{}
+}
+
+func String() AtomicSchema[string, string] { return AtomicSchema[string, string]{} }
+
+func StringFrom[T comparable]() AtomicSchema[T, string] { return AtomicSchema[T, string]{} }
+
+func (s AtomicSchema[T, R]) OmitZero() AtomicSchema[T, R] { return s }
+
+type StructSchema[T any] struct{}
+
+func Struct[T any](fields M) StructSchema[T] { return StructSchema[T]{} }
+
+func (s StructSchema[T]) Extend(fields M) StructSchema[T] { return s }
+
+type SliceSchema[S ~[]T, T any] struct{}
+
+func Slice[S ~[]T, T any](inner Schema) SliceSchema[S, T] { return SliceSchema[S, T]{} }
+
+type PtrSchema[To any] struct{}
+
+func Ptr[To any](inner Schema) PtrSchema[To] { return PtrSchema[To]{} }
+
+type LazySchema[T any] struct{}
+
+func Lazy[T any](fn func() Schema) LazySchema[T] { return LazySchema[T]{} }
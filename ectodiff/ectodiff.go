@@ -0,0 +1,385 @@
+// Package ectodiff compares snapshots of schemas (see ecto.Inspect) and classifies changes of API contract.
+// Tightened rules (ex. str.Max(100) -> str.Max(50), new required field) break clients sending requests,
+// loosened ones (ex. removed test, optional field) break clients reading responses:
+//
+//	changes := ectodiff.Compare(ecto.Inspect(oldSchema), ecto.Inspect(newSchema))
+//	if breaking := ectodiff.Breaking(changes, ectodiff.Request); len(breaking) > 0 { ... }
+//
+// Parameters of known tests are compared by meaning (bounds, sets of allowed values), any other change of
+// parameters is incompatible
+package ectodiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/egsam98/ecto"
+)
+
+// Effect of schema change on accepted data
+type Effect string
+
+const (
+	// Compatible change doesn't affect accepted data, ex. changed error message or transforms
+	Compatible Effect = "compatible"
+	// Tightened schema rejects some data accepted before
+	Tightened Effect = "tightened"
+	// Loosened schema accepts some data rejected before
+	Loosened Effect = "loosened"
+	// Incompatible change both rejects and accepts new data, ex. changed type or regex
+	Incompatible Effect = "incompatible"
+)
+
+// join combines effects of several changes of the same rule
+func (e Effect) join(other Effect) Effect {
+	switch {
+	case e == Compatible || e == other:
+		return other
+	case other == Compatible:
+		return e
+	default:
+		return Incompatible
+	}
+}
+
+// Usage of schema in API contract
+type Usage string
+
+const (
+	// Request schema validates data sent by clients
+	Request Usage = "request"
+	// Response schema describes data read by clients
+	Response Usage = "response"
+)
+
+// Change of schema at path consisting of field tags, "[]" for slice elements, "*" for pointees and "~" for
+// resolved lazy schemas (see ecto.Node.Walk)
+type Change struct {
+	Path    []string `json:"path"`
+	Effect  Effect   `json:"effect"`
+	Message string   `json:"message"`
+}
+
+// Breaking reports whether change breaks clients of schema used as request or response
+func (c Change) Breaking(usage Usage) bool {
+	switch c.Effect {
+	case Incompatible:
+		return true
+	case Tightened:
+		return usage == Request
+	case Loosened:
+		return usage == Response
+	default:
+		return false
+	}
+}
+
+func (c Change) String() string {
+	if len(c.Path) == 0 {
+		return fmt.Sprintf("%s (%s)", c.Message, c.Effect)
+	}
+	return fmt.Sprintf("%s: %s (%s)", strings.Join(c.Path, "."), c.Message, c.Effect)
+}
+
+// Breaking filters changes breaking clients of schema used as request or response
+func Breaking(changes []Change, usage Usage) []Change {
+	return lo.Filter(changes, func(change Change, _ int) bool { return change.Breaking(usage) })
+}
+
+// Compare lists changes from old to new schema snapshot. Struct fields are matched by tags
+func Compare(old, new ecto.Node) []Change {
+	var d differ
+	d.node(nil, &old, &new)
+	return d.changes
+}
+
+var elemPath = map[ecto.NodeKind]string{ecto.KindSlice: "[]", ecto.KindPtr: "*", ecto.KindLazy: "~"}
+
+type differ struct {
+	changes []Change
+}
+
+func (d *differ) add(path []string, effect Effect, format string, args ...any) {
+	d.changes = append(d.changes, Change{Path: slices.Clone(path), Effect: effect, Message: fmt.Sprintf(format, args...)})
+}
+
+func (d *differ) node(path []string, old, new *ecto.Node) {
+	if old.Kind != new.Kind || old.Type != new.Type || old.Result != new.Result {
+		d.add(path, Incompatible, "type changed from %s to %s", nodeType(old), nodeType(new))
+		return
+	}
+
+	switch {
+	case !old.Required && new.Required:
+		d.add(path, Tightened, "became required")
+	case old.Required && !new.Required:
+		d.add(path, Loosened, "became optional")
+	}
+
+	switch {
+	case old.Default == nil && new.Default != nil:
+		d.add(path, lo.Ternary(zeroTested(old), Loosened, Compatible), "default added")
+	case old.Default != nil && new.Default == nil:
+		d.add(path, lo.Ternary(zeroTested(new), Tightened, Compatible), "default removed")
+	case old.Default != nil && !reflect.DeepEqual(jsonValue(old.Default), jsonValue(new.Default)):
+		d.add(path, Compatible, "default changed")
+	}
+
+	if old.OmitZero != new.OmitZero {
+		d.add(path, omitZeroEffect(old, new), "omitzero %s", lo.Ternary(new.OmitZero, "enabled", "disabled"))
+	}
+	if old.Transforms != new.Transforms {
+		d.add(path, Compatible, "transforms changed from %d to %d", old.Transforms, new.Transforms)
+	}
+	if old.MaxDepth != new.MaxDepth {
+		// Zero depth is unlimited
+		effect := Loosened
+		if new.MaxDepth != 0 && (old.MaxDepth == 0 || new.MaxDepth < old.MaxDepth) {
+			effect = Tightened
+		}
+		d.add(path, effect, "max depth changed from %d to %d", old.MaxDepth, new.MaxDepth)
+	}
+
+	d.tests(path, old.Tests, new.Tests)
+	if old.Recursive || new.Recursive {
+		return
+	}
+	d.fields(path, old.Fields, new.Fields)
+	if old.Elem != nil && new.Elem != nil {
+		d.node(append(slices.Clip(path), elemPath[old.Kind]), old.Elem, new.Elem)
+	}
+}
+
+func (d *differ) fields(path []string, old, new []ecto.FieldNode) {
+	newByTag := lo.SliceToMap(new, func(field ecto.FieldNode) (string, *ecto.Node) { return field.Tag, &field.Node })
+	for _, field := range old {
+		fieldPath := append(slices.Clip(path), field.Tag)
+		if node, ok := newByTag[field.Tag]; ok {
+			d.node(fieldPath, &field.Node, node)
+		} else {
+			d.add(fieldPath, Loosened, "field removed")
+		}
+	}
+
+	oldTags := lo.SliceToMap(old, func(field ecto.FieldNode) (string, bool) { return field.Tag, true })
+	for _, field := range new {
+		if oldTags[field.Tag] {
+			continue
+		}
+		fieldPath := append(slices.Clip(path), field.Tag)
+		if field.Node.Required {
+			d.add(fieldPath, Tightened, "required field added")
+		} else {
+			d.add(fieldPath, Compatible, "optional field added")
+		}
+	}
+}
+
+// omitZeroEffect of zero value skipping tests and default. It's compatible if the value is required or nothing is
+// skipped. Otherwise enabled OmitZero accepts zero rejected by tests and leaves it instead of default in responses
+func omitZeroEffect(old, new *ecto.Node) Effect {
+	affected := func(node *ecto.Node) bool { return !node.Required && (len(node.Tests) > 0 || node.Default != nil) }
+	switch {
+	case !affected(old) && !affected(new):
+		return Compatible
+	case new.OmitZero:
+		return Loosened
+	default:
+		return Tightened
+	}
+}
+
+// zeroTested reports whether zero value without default is checked by tests, so default replacing it matters
+func zeroTested(node *ecto.Node) bool {
+	return !node.Required && !node.OmitZero && len(node.Tests) > 0
+}
+
+// tests matches tests by code (error for custom ones) in order of declaration
+func (d *differ) tests(path []string, old, new []ecto.TestNode) {
+	key := func(test ecto.TestNode) string {
+		if test.Code == "" {
+			return "error:" + string(test.Error)
+		}
+		return test.Code
+	}
+
+	newByKey := lo.GroupBy(new, key)
+	matched := make(map[string]int)
+	for _, oldTest := range old {
+		k := key(oldTest)
+		if matched[k] == len(newByKey[k]) {
+			d.add(path, Loosened, "test %s removed", oldTest)
+			continue
+		}
+		newTest := newByKey[k][matched[k]]
+		matched[k]++
+
+		// Error of parametrized test usually changes along with parameters
+		if effect := compareParams(oldTest, newTest); effect != Compatible {
+			d.add(path, effect, "test %s changed to %s", oldTest, newTest)
+		} else if oldTest.Error != newTest.Error {
+			d.add(path, Compatible, "error of test %s changed from %q to %q", newTest, oldTest.Error, newTest.Error)
+		}
+	}
+
+	// Tests left unmatched are the last ones of their codes
+	seen := make(map[string]int)
+	for _, newTest := range new {
+		k := key(newTest)
+		if seen[k]++; seen[k] > matched[k] {
+			d.add(path, Tightened, "test %s added", newTest)
+		}
+	}
+}
+
+type bound int
+
+const (
+	// lowerBound parameter rejects values less than it
+	lowerBound bound = iota + 1
+	// upperBound parameter rejects values greater than it
+	upperBound
+	// allowedSet parameter lists the only accepted values
+	allowedSet
+	// forbiddenSet parameter lists rejected values
+	forbiddenSet
+)
+
+// bounds of built-in tests' parameters, changes of other parameters are incompatible
+var bounds = map[string]map[string]bound{
+	"strings.Min":                  {"length": lowerBound},
+	"strings.Max":                  {"length": upperBound},
+	"strings.MinGraphemes":         {"length": lowerBound},
+	"strings.MaxGraphemes":         {"length": upperBound},
+	"strings.MinBytes":             {"length": lowerBound},
+	"strings.MaxBytes":             {"length": upperBound},
+	"strings.Runes":                {"names": allowedSet},
+	"slices.Min":                   {"length": lowerBound},
+	"slices.Max":                   {"length": upperBound},
+	"ints.Min":                     {"value": lowerBound},
+	"ints.Max":                     {"value": upperBound},
+	"ints.MinExclusive":            {"value": lowerBound},
+	"ints.MaxExclusive":            {"value": upperBound},
+	"ints.Between":                 {"min": lowerBound, "max": upperBound},
+	"floats.Min":                   {"value": lowerBound},
+	"floats.Max":                   {"value": upperBound},
+	"floats.MaxPrecision":          {"value": upperBound},
+	"decimals.Min":                 {"value": lowerBound},
+	"decimals.Max":                 {"value": upperBound},
+	"decimals.MaxScale":            {"value": upperBound},
+	"decimals.MaxPrecision":        {"value": upperBound},
+	"decimals.MaxIntegerDigits":    {"value": upperBound},
+	"times.Before":                 {"value": upperBound},
+	"times.After":                  {"value": lowerBound},
+	"times.Between":                {"from": lowerBound, "to": upperBound},
+	"times.Weekday":                {"days": allowedSet},
+	"times.MinAge":                 {"years": lowerBound},
+	"times.MaxAge":                 {"years": upperBound},
+	"times.MinDuration":            {"value": lowerBound},
+	"times.MaxDuration":            {"value": upperBound},
+	"network.PrefixBits":           {"min": lowerBound, "max": upperBound},
+	"password.MinLength":           {"length": lowerBound},
+	"password.MaxRepeated":         {"count": upperBound},
+	"password.NoSequences":         {"length": upperBound},
+	"password.MinEntropy":          {"bits": lowerBound},
+	"password.NotContainingFields": {"names": forbiddenSet},
+	"ecto.OneOf":                   {"variants": allowedSet},
+	"uuids.Version":                {"versions": allowedSet},
+	"uuids.Variant":                {"variants": allowedSet},
+	"finance.CardBrand":            {"brands": allowedSet},
+	"finance.IBANCountry":          {"codes": allowedSet},
+}
+
+func compareParams(old, new ecto.TestNode) Effect {
+	effect := Compatible
+	for _, key := range lo.Union(lo.Keys(old.Params), lo.Keys(new.Params)) {
+		oldValue, newValue := jsonValue(old.Params[key]), jsonValue(new.Params[key])
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		effect = effect.join(compareParam(bounds[old.Code][key], oldValue, newValue))
+	}
+	return effect
+}
+
+func compareParam(b bound, old, new any) Effect {
+	switch b {
+	case lowerBound, upperBound:
+		oldNum, ok1 := number(old)
+		newNum, ok2 := number(new)
+		if !ok1 || !ok2 {
+			return Incompatible
+		}
+		if (newNum > oldNum) == (b == lowerBound) {
+			return Tightened
+		}
+		return Loosened
+	case allowedSet, forbiddenSet:
+		oldSet, ok1 := set(old)
+		newSet, ok2 := set(new)
+		if !ok1 || !ok2 {
+			return Incompatible
+		}
+		removed, added := lo.Difference(oldSet, newSet)
+		effect := Compatible
+		if len(removed) > 0 {
+			effect = effect.join(lo.Ternary(b == allowedSet, Tightened, Loosened))
+		}
+		if len(added) > 0 {
+			effect = effect.join(lo.Ternary(b == allowedSet, Loosened, Tightened))
+		}
+		return effect
+	default:
+		return Incompatible
+	}
+}
+
+// jsonValue normalizes value as if it's decoded from snapshot, so in-memory and decoded nodes are comparable
+func jsonValue(value any) any {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	var res any
+	_ = json.Unmarshal(data, &res)
+	return res
+}
+
+// number converts JSON number, numeric string (ex. decimal) or RFC 3339 time to float
+func number(value any) (float64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case string:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f, true
+		}
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return float64(t.UnixNano()), true
+		}
+	}
+	return 0, false
+}
+
+func set(value any) ([]string, bool) {
+	values, ok := value.([]any)
+	if !ok {
+		return nil, false
+	}
+	return lo.Map(values, func(v any, _ int) string { return fmt.Sprint(v) }), true
+}
+
+func nodeType(node *ecto.Node) string {
+	typ := string(node.Kind) + " " + node.Type
+	if node.Result != "" {
+		typ += " -> " + node.Result
+	}
+	return typ
+}
@@ -0,0 +1,407 @@
+// Package ectots exports struct schemas as TypeScript interfaces and Zod validators (zod v3), so frontend forms
+// validate data identically with the same error messages:
+//
+//	//go:generate go run ./cmd/export-ts
+//	err := ectots.Export(file, userSchema, orderSchema)
+//
+// Object keys are field tags (see ecto.FieldMeta), named struct types become separate declarations. Fields are
+// optional unless their schemas are required. Supported tests are min/max/length of strings and slices, regex,
+// email, url, ip, numeric bounds and ecto.OneOf (exported as enum), the other ones are listed in comments of
+// declarations. Transforms aren't exported
+package ectots
+
+import (
+	"cmp"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/egsam98/errors"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+
+	"github.com/egsam98/ecto"
+)
+
+var (
+	typeTime          = reflect.TypeFor[time.Time]()
+	typeUUID          = reflect.TypeFor[uuid.UUID]()
+	typeDecimal       = reflect.TypeFor[decimal.Decimal]()
+	typeTextMarshaler = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// Export writes TypeScript module declaring interface and Zod schema (named <Type>Schema) for every struct schema
+// and named struct types nested in them
+func Export(w io.Writer, schemas ...ecto.Schema) error {
+	e := exporter{names: make(map[string]bool)}
+	for _, schema := range schemas {
+		typ := schema.ForType()
+		node := ecto.Inspect(schema)
+		if node.Kind != ecto.KindStruct || typ.Name() == "" {
+			return errors.Errorf("%s: expected schema of named struct type", typ)
+		}
+		e.structDecl(typ, &node)
+	}
+
+	_, err := io.WriteString(w, "// Code generated by ectots. DO NOT EDIT.\n\nimport { z } from \"zod\";\n"+
+		strings.Join(e.decls, ""))
+	return err
+}
+
+type exporter struct {
+	// decls are rendered in order of dependencies
+	decls    []string
+	names    map[string]bool
+	declared []declared
+	// ancestors are structs being rendered, lazy schemas of their types are references
+	ancestors []declared
+}
+
+type declared struct {
+	typ  reflect.Type
+	node *ecto.Node
+	name string
+}
+
+// zodType is rendered Zod expression with corresponding TypeScript type
+type zodType struct {
+	zod, ts string
+	// optional value is omitted or undefined
+	optional bool
+}
+
+// structDecl renders interface and schema of struct type once per distinct node and returns their name
+func (e *exporter) structDecl(typ reflect.Type, node *ecto.Node) string {
+	for _, decl := range e.declared {
+		if decl.typ == typ && reflect.DeepEqual(decl.node, node) {
+			return decl.name
+		}
+	}
+
+	decl := declared{typ: typ, node: node, name: e.uniqueName(typ)}
+	e.declared = append(e.declared, decl)
+	e.ancestors = append(e.ancestors, decl)
+	defer func() { e.ancestors = e.ancestors[:len(e.ancestors)-1] }()
+
+	var iface, schema strings.Builder
+	var unsupported []string
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		jsonTag, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || jsonTag == "-" {
+			continue
+		}
+
+		key := cmp.Or(jsonTag, field.Name)
+		var value zodType
+		if fieldNode, ok := lo.Find(node.Fields, func(f ecto.FieldNode) bool { return f.Name == field.Name }); ok {
+			key = fieldNode.Tag
+			value = e.value(field.Type, &fieldNode.Node, func(test ecto.TestNode) {
+				unsupported = append(unsupported, key+": "+test.String())
+			})
+		} else {
+			value = baseType(field.Type)
+			value.optional = true
+		}
+		// Marshaled JSON may omit the field
+		if opts := strings.Split(opts, ","); slices.Contains(opts, "omitempty") || slices.Contains(opts, "omitzero") {
+			value.optional = true
+		}
+
+		zod := value.zod
+		if value.optional {
+			zod += ".optional()"
+		}
+		fmt.Fprintf(&iface, "  %s%s: %s;\n", propertyKey(key), lo.Ternary(value.optional, "?", ""), value.ts)
+		fmt.Fprintf(&schema, "  %s: %s,\n", propertyKey(key), zod)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nexport interface %s {\n%s}\n\n", decl.name, iface.String())
+	for _, test := range unsupported {
+		fmt.Fprintf(&b, "// Not exported test of %s\n", test)
+	}
+	fmt.Fprintf(&b, "export const %sSchema: z.ZodType<%[1]s, z.ZodTypeDef, unknown> = z.object({\n%s});\n",
+		decl.name, schema.String())
+	e.decls = append(e.decls, b.String())
+	return decl.name
+}
+
+// value renders schema of type described by node, unsupported tests are reported
+func (e *exporter) value(typ reflect.Type, node *ecto.Node, unsupported func(ecto.TestNode)) zodType {
+	// Recursive schemas refer to the nearest declaration of their type
+	if node.Kind == ecto.KindLazy || node.Recursive {
+		for _, ancestor := range slices.Backward(e.ancestors) {
+			if ancestor.typ == typ {
+				return zodType{zod: "z.lazy(() => " + ancestor.name + "Schema)", ts: ancestor.name}
+			}
+		}
+		if node.Recursive {
+			return zodType{zod: "z.unknown()", ts: "unknown"}
+		}
+	}
+	switch node.Kind {
+	case ecto.KindStruct:
+		name := e.structDecl(typ, node)
+		return zodType{zod: name + "Schema", ts: name}
+	case ecto.KindLazy:
+		return e.value(typ, node.Elem, unsupported)
+	case ecto.KindSlice:
+		elem := e.value(typ.Elem(), node.Elem, unsupported)
+		value := zodType{zod: "z.array(" + elem.zod + ")", ts: arrayType(elem.ts)}
+		for _, test := range node.Tests {
+			if pkg, name, _ := strings.Cut(test.Code, "."); pkg == "slices" && lengthMethods[name] != "" {
+				value.zod += fmt.Sprintf(".%s(%v, %s)", lengthMethods[name], test.Params["length"], jsonString(test.Error))
+			} else {
+				unsupported(test)
+			}
+		}
+		// Nil slice is encoded as null
+		return zodType{zod: value.zod + ".nullable()", ts: value.ts + " | null", optional: true}
+	case ecto.KindPtr:
+		elem := e.value(typ.Elem(), node.Elem, unsupported)
+		if node.Required {
+			return zodType{zod: elem.zod, ts: elem.ts}
+		}
+		value := zodType{zod: elem.zod + ".nullable()", ts: elem.ts + " | null", optional: true}
+		return withDefault(value, node.Default, "null")
+	default:
+		return e.atomic(typ, node, unsupported)
+	}
+}
+
+var lengthMethods = map[string]string{"Min": "min", "Max": "max", "Len": "length"}
+
+// numberMethods of Zod by names of ints and floats tests with their parameters
+var numberMethods = map[string][2]string{
+	"Min":          {"min", "value"},
+	"Max":          {"max", "value"},
+	"MinExclusive": {"gt", "value"},
+	"MaxExclusive": {"lt", "value"},
+	"MultipleOf":   {"multipleOf", "step"},
+	"Positive":     {"positive"},
+	"NonNegative":  {"nonnegative"},
+}
+
+var stringFormats = map[string]string{
+	"strings.Email":     "email",
+	"strings.URLWith":   "url",
+	"strings.ParsedURL": "url",
+	"strings.IP":        "ip",
+}
+
+func (e *exporter) atomic(typ reflect.Type, node *ecto.Node, unsupported func(ecto.TestNode)) zodType {
+	value := baseType(typ)
+	// Tests of converted values (see ecto.AtomicFrom) don't apply to JSON ones
+	if node.Result != "" {
+		lo.ForEach(node.Tests, func(test ecto.TestNode, _ int) { unsupported(test) })
+		return withZero(value, node, zeroLiteral(typ))
+	}
+
+	tests := node.Tests
+	isString := typ.Kind() == reflect.String
+	isNumber := value.ts == "number"
+	// Single OneOf test is exported as enum, otherwise as refinement
+	if len(tests) == 1 && tests[0].Code == "ecto.OneOf" && (isString || isNumber || typ.Kind() == reflect.Bool) {
+		variants := reflect.ValueOf(tests[0].Params["variants"])
+		literals := make([]string, variants.Len())
+		for j := range literals {
+			literals[j] = jsonString(variants.Index(j).Interface())
+		}
+		value.ts = strings.Join(literals, " | ")
+		if isString {
+			value.zod = fmt.Sprintf("z.enum([%s], { message: %s })", strings.Join(literals, ", "), jsonString(tests[0].Error))
+		} else {
+			value.zod = "z.union([" + strings.Join(lo.Map(literals, func(literal string, _ int) string {
+				return "z.literal(" + literal + ")"
+			}), ", ") + "])"
+		}
+		return withZero(value, node, zeroLiteral(typ))
+	}
+
+	if node.Required {
+		switch {
+		case isString:
+			value.zod += `.min(1, "required")`
+		case isNumber:
+			value.zod += `.refine((v) => v !== 0, "required")`
+		case typ.Kind() == reflect.Bool:
+			value.zod += `.refine((v) => v, "required")`
+		}
+	}
+
+	for _, test := range tests {
+		pkg, name, _ := strings.Cut(test.Code, ".")
+		msg := jsonString(test.Error)
+		switch {
+		case isString && pkg == "strings" && lengthMethods[name] != "":
+			value.zod += fmt.Sprintf(".%s(%v, %s)", lengthMethods[name], test.Params["length"], msg)
+		case isString && test.Code == "strings.Regex":
+			value.zod += fmt.Sprintf(".regex(new RegExp(%s), %s)", jsonString(jsRegex(test.Params["regex"])), msg)
+		case isString && stringFormats[test.Code] != "":
+			value.zod += fmt.Sprintf(".%s(%s)", stringFormats[test.Code], msg)
+		case isString && test.Code == "strings.DateTime" && test.Params["layout"] == time.RFC3339:
+			value.zod += fmt.Sprintf(".datetime({ offset: true, message: %s })", msg)
+		case isNumber && (pkg == "ints" || pkg == "floats") && name == "Between":
+			value.zod += fmt.Sprintf(".min(%v, %s).max(%v, %[2]s)", test.Params["min"], msg, test.Params["max"])
+		case isNumber && (pkg == "ints" || pkg == "floats") && numberMethods[name][0] != "":
+			method, param := numberMethods[name][0], numberMethods[name][1]
+			if param == "" {
+				value.zod += fmt.Sprintf(".%s(%s)", method, msg)
+			} else {
+				value.zod += fmt.Sprintf(".%s(%v, %s)", method, test.Params[param], msg)
+			}
+		case test.Code == "ecto.OneOf":
+			values := jsonString(test.Params["variants"])
+			value.zod += fmt.Sprintf(".refine((v) => %s.includes(v), %s)", values, msg)
+		default:
+			unsupported(test)
+		}
+	}
+	return withZero(value, node, zeroLiteral(typ))
+}
+
+// withZero applies handling of zero value (absent in JSON) by atomic schema: it fails required one, skips tests of
+// OmitZero one, replaces it with default or validates by tests. Value is required if it has tests (see
+// ecto.AtomicSchema.IsRequired), zero is its JS literal or empty if type has no such literal
+func withZero(value zodType, node *ecto.Node, zero string) zodType {
+	switch {
+	case node.Required:
+		value.optional = false
+	case node.OmitZero:
+		value.zod += ".optional()"
+		if zero != "" {
+			value.zod = fmt.Sprintf("z.preprocess((v) => (v === %s ? undefined : v), %s)", zero, value.zod)
+		}
+		value.optional = true
+	case node.Default != nil:
+		value = withDefault(value, node.Default, zero)
+	default:
+		value.optional = len(node.Tests) == 0
+	}
+	return value
+}
+
+// baseType renders JSON representation of Go type
+func baseType(typ reflect.Type) zodType {
+	switch typ {
+	case typeTime:
+		return zodType{zod: "z.string().datetime({ offset: true })", ts: "string"}
+	case typeUUID:
+		return zodType{zod: "z.string().uuid()", ts: "string"}
+	case typeDecimal:
+		return zodType{zod: "z.string()", ts: "string"}
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return zodType{zod: "z.string()", ts: "string"}
+	case reflect.Bool:
+		return zodType{zod: "z.boolean()", ts: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return zodType{zod: "z.number().int()", ts: "number"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return zodType{zod: "z.number().int().nonnegative()", ts: "number"}
+	case reflect.Float32, reflect.Float64:
+		return zodType{zod: "z.number()", ts: "number"}
+	case reflect.Slice:
+		// Bytes are encoded in base64
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return zodType{zod: "z.string()", ts: "string"}
+		}
+		elem := baseType(typ.Elem())
+		return zodType{zod: "z.array(" + elem.zod + ").nullable()", ts: arrayType(elem.ts) + " | null"}
+	case reflect.Pointer:
+		elem := baseType(typ.Elem())
+		return zodType{zod: elem.zod + ".nullable()", ts: elem.ts + " | null"}
+	}
+
+	if typ.Implements(typeTextMarshaler) {
+		return zodType{zod: "z.string()", ts: "string"}
+	}
+	return zodType{zod: "z.unknown()", ts: "unknown"}
+}
+
+// withDefault makes value optional in input. Constant default is applied by Zod, generated one is left to backend.
+// As in Go, zero value is defaulted too, zero is its JS literal or empty if schema doesn't default it
+func withDefault(value zodType, def *ecto.DefaultNode, zero string) zodType {
+	if def == nil {
+		return value
+	}
+	if def.Generated {
+		value.zod += ".optional()"
+		value.optional = true
+	} else {
+		value.zod += ".default(" + jsonString(def.Value) + ")"
+		value.optional = false
+	}
+	if zero != "" {
+		value.zod = fmt.Sprintf("z.preprocess((v) => (v === %s ? undefined : v), %s)", zero, value.zod)
+	}
+	return value
+}
+
+// zeroLiteral of JSON representation of Go zero value of atomic type
+func zeroLiteral(typ reflect.Type) string {
+	switch kind := typ.Kind(); {
+	case kind == reflect.String:
+		return `""`
+	case kind == reflect.Bool:
+		return "false"
+	case kind >= reflect.Int && kind <= reflect.Float64:
+		return "0"
+	default:
+		return ""
+	}
+}
+
+// nameRegex matches characters of Go type names invalid in TypeScript ones.
+// Type arguments are listed in name of generic type, ex. "Page[main.User]"
+var nameRegex = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+func (e *exporter) uniqueName(typ reflect.Type) string {
+	base := nameRegex.ReplaceAllString(typ.Name(), "_")
+	base = strings.TrimRight(base, "_")
+	name := base
+	for i := 2; e.names[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	e.names[name] = true
+	return name
+}
+
+var identRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+func propertyKey(key string) string {
+	if identRegex.MatchString(key) {
+		return key
+	}
+	return jsonString(key)
+}
+
+func arrayType(elem string) string {
+	if strings.ContainsAny(elem, " |") {
+		return "(" + elem + ")[]"
+	}
+	return elem + "[]"
+}
+
+// jsRegex drops Go-specific syntax unsupported by JavaScript: \A and \z anchors
+func jsRegex(regex any) string {
+	return strings.NewReplacer(`\A`, `^`, `\z`, `$`).Replace(fmt.Sprint(regex))
+}
+
+func jsonString(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
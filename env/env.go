@@ -0,0 +1,256 @@
+// Package env loads configuration structs from environment variables and validates them by ecto.StructSchema.
+// Variables are named by `env` tags or field names in upper snake case (MaxConns as MAX_CONNS) joined with
+// prefixes of parent structs by "_", ex. APP_DB_PORT for field Port of field DB with prefix "APP":
+//
+//	type Config struct {
+//		DB    DB            `json:"db"`
+//		Hosts []string      `env:"HOSTS"`
+//		TTL   time.Duration `env:"TTL"`
+//	}
+//
+// Embedded structs share prefix of parent one, "-" tag skips a field. Supported types are strings, booleans,
+// numbers, time.Duration, encoding.TextUnmarshaler implementations (time.Time, uuid.UUID, decimal.Decimal etc.),
+// pointers to and separated lists of them. Unset and empty variables leave zero values, so defaults of schemas
+// (see ecto.AtomicSchema.Default) are applied
+package env
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/egsam98/errors"
+
+	"github.com/egsam98/ecto"
+	"github.com/egsam98/ecto/internal/text"
+)
+
+type Opt func(*config)
+
+// Prefix of variables, ex. "APP" for APP_PORT
+func Prefix(prefix string) Opt {
+	return func(cfg *config) { cfg.prefix = prefix }
+}
+
+// Separator of list items ("," by default)
+func Separator(sep string) Opt {
+	return func(cfg *config) { cfg.sep = sep }
+}
+
+// Lookup replaces os.LookupEnv, ex. to read variables from map
+func Lookup(fn func(name string) (string, bool)) Opt {
+	return func(cfg *config) { cfg.lookup = fn }
+}
+
+// Context passes ctx to schema processing loaded struct
+func Context(ctx context.Context) Opt {
+	return func(cfg *config) { cfg.ctx = ctx }
+}
+
+type config struct {
+	prefix, sep string
+	lookup      func(string) (string, bool)
+	ctx         context.Context
+}
+
+// Load populates T from environment variables and processes it by schema. Malformed variables and
+// processing errors are reported as Error
+func Load[T any](schema ecto.StructSchema[T], opts ...Opt) (T, error) {
+	cfg := config{sep: ",", lookup: os.LookupEnv, ctx: context.Background()}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+
+	var data T
+	l := loader{cfg: cfg, errs: make(Error)}
+	rv := reflect.ValueOf(&data).Elem()
+	if _, err := l.loadStruct(rv, cfg.prefix); err != nil {
+		return data, err
+	}
+	if len(l.errs) > 0 {
+		return data, l.errs
+	}
+
+	if err := schema.ProcessContext(cfg.ctx, &data); err != nil {
+		l.addErrors(err, rv.Type(), cfg.prefix)
+		return data, l.errs
+	}
+	return data, nil
+}
+
+// Error lists messages by names of variables, ex. "APP_DB_PORT: must be 1 minimum"
+type Error map[string]ecto.ListError
+
+func (e Error) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, name := range slices.Sorted(maps.Keys(e)) {
+		for _, msg := range e[name] {
+			lines = append(lines, name+": "+string(msg))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+type loader struct {
+	cfg  config
+	errs Error
+}
+
+// loadStruct reports whether any variable of struct is set
+func (l *loader) loadStruct(rv reflect.Value, prefix string) (bool, error) {
+	var set bool
+	for field, name := range fields(rv.Type(), prefix) {
+		fieldSet, err := l.load(rv.FieldByIndex(field.Index), name)
+		if err != nil {
+			return false, errors.Wrapf(err, "%s.%s", rv.Type(), field.Name)
+		}
+		set = set || fieldSet
+	}
+	return set, nil
+}
+
+func (l *loader) load(rv reflect.Value, name string) (bool, error) {
+	typ := rv.Type()
+	switch {
+	case text.Parsable(typ) || typ.Kind() == reflect.Pointer && text.Parsable(typ.Elem()):
+	case typ.Kind() == reflect.Slice && text.Parsable(typ.Elem()):
+	case typ.Kind() == reflect.Struct:
+		return l.loadStruct(rv, name)
+	case typ.Kind() == reflect.Pointer && typ.Elem().Kind() == reflect.Struct:
+		ptr := reflect.New(typ.Elem())
+		set, err := l.loadStruct(ptr.Elem(), name)
+		if set {
+			rv.Set(ptr)
+		}
+		return set, err
+	default:
+		return false, errors.Errorf("unsupported type %s", typ)
+	}
+
+	value, _ := l.cfg.lookup(name)
+	if value == "" {
+		return false, nil
+	}
+
+	if typ.Kind() != reflect.Slice {
+		if err := text.Parse(rv, value); err != nil {
+			l.errs[name] = append(l.errs[name], *err)
+		}
+		return true, nil
+	}
+
+	items := strings.Split(value, l.cfg.sep)
+	slice := reflect.MakeSlice(typ, len(items), len(items))
+	for i, item := range items {
+		if err := text.Parse(slice.Index(i), strings.TrimSpace(item)); err != nil {
+			l.errs[name] = append(l.errs[name], ecto.Errorf("%d: %s", i, *err))
+		}
+	}
+	rv.Set(slice)
+	return true, nil
+}
+
+// addErrors adds processing errors keyed by struct tags (see ecto.FieldMeta) under names of variables
+func (l *loader) addErrors(err error, typ reflect.Type, name string) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch err := err.(type) {
+	case ecto.ListError:
+		l.errs[name] = append(l.errs[name], err...)
+	case ecto.MapError:
+		if typ.Kind() == reflect.Slice {
+			l.errs[name] = append(l.errs[name], text.Flatten(err)...)
+			return
+		}
+
+		names := make(map[string]string)
+		types := make(map[string]reflect.Type)
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			if fieldName, ok := varName(field, name); ok {
+				tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+				tag = cmp.Or(tag, field.Name)
+				names[tag], types[tag] = fieldName, field.Type
+			}
+		}
+		for key, err := range err {
+			if fieldName, ok := names[key]; ok {
+				l.addErrors(err, types[key], fieldName)
+			} else {
+				l.errs[join(name, key)] = append(l.errs[join(name, key)], text.Flatten(err)...)
+			}
+		}
+	}
+}
+
+// fields iterates over loaded fields of struct type with names of their variables. Embedded structs are flattened
+func fields(typ reflect.Type, prefix string) func(yield func(reflect.StructField, string) bool) {
+	return func(yield func(reflect.StructField, string) bool) {
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			name, ok := varName(field, prefix)
+			switch {
+			case !ok:
+			case isEmbedded(field):
+				for embedded, name := range fields(field.Type, name) {
+					embedded.Index = append([]int{i}, embedded.Index...)
+					if !yield(embedded, name) {
+						return
+					}
+				}
+			default:
+				if !yield(field, name) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// varName of field or prefix of its fields. Unexported fields and ones tagged by "-" are skipped
+func varName(field reflect.StructField, prefix string) (string, bool) {
+	tag := field.Tag.Get("env")
+	switch {
+	case !field.IsExported() || tag == "-":
+		return "", false
+	case isEmbedded(field):
+		return prefix, true
+	default:
+		return join(prefix, cmp.Or(tag, snakeCase(field.Name))), true
+	}
+}
+
+// isEmbedded reports whether fields of embedded struct share prefix of parent one
+func isEmbedded(field reflect.StructField) bool {
+	return field.Anonymous && field.Tag.Get("env") == "" && field.Type.Kind() == reflect.Struct && !text.Parsable(field.Type)
+}
+
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
+
+// snakeCase converts Go name to upper snake case keeping acronyms, ex. HTTPServer as HTTP_SERVER
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
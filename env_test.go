@@ -0,0 +1,111 @@
+package ecto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egsam98/ecto"
+	"github.com/egsam98/ecto/env"
+	integer "github.com/egsam98/ecto/ints"
+	ectosl "github.com/egsam98/ecto/slices"
+	ectos "github.com/egsam98/ecto/strings"
+)
+
+type EnvConfig struct {
+	EnvBase
+	DB      EnvDB         `json:"db"`
+	Replica *EnvDB        `json:"replica"`
+	Hosts   []string      `json:"hosts" env:"HOSTS"`
+	TTL     time.Duration `json:"ttl" env:"TTL"`
+	Debug   *bool         `json:"debug"`
+	Skipped string        `env:"-"`
+}
+
+type EnvBase struct {
+	ServiceID uuid.UUID `json:"serviceId"`
+}
+
+type EnvDB struct {
+	Port     int    `json:"port"`
+	HTTPUser string `json:"user"`
+}
+
+var envSchema = ecto.Struct[EnvConfig](ecto.M{
+	"EnvBase": ecto.Struct[EnvBase](ecto.M{"ServiceID": ecto.Atomic[uuid.UUID]().Required()}),
+	"DB": ecto.Struct[EnvDB](ecto.M{
+		"Port":     ecto.Int().Default(5432).Test(integer.Between(1, 65535)),
+		"HTTPUser": ecto.String().Required(),
+	}),
+	"Hosts": ecto.Slice[[]string](ecto.String().Test(ectos.Min(3))).Test(ectosl.Max[[]string](3)),
+	"TTL":   ecto.Atomic[time.Duration]().Default(time.Minute),
+})
+
+func TestLoadEnv(t *testing.T) {
+	id := uuid.New()
+	t.Setenv("APP_SERVICE_ID", id.String())
+	t.Setenv("APP_DB_HTTP_USER", "root")
+	t.Setenv("APP_REPLICA_PORT", "5433")
+	t.Setenv("APP_HOSTS", "foo.com, bar.com")
+	t.Setenv("APP_DEBUG", "true")
+	t.Setenv("APP_SKIPPED", "value")
+
+	cfg, err := env.Load(envSchema, env.Prefix("APP"))
+	require.NoError(t, err)
+	assert.Equal(t, EnvConfig{
+		EnvBase: EnvBase{ServiceID: id},
+		DB:      EnvDB{Port: 5432, HTTPUser: "root"},
+		Replica: &EnvDB{Port: 5433},
+		Hosts:   []string{"foo.com", "bar.com"},
+		TTL:     time.Minute,
+		Debug:   lo.ToPtr(true),
+	}, cfg)
+
+	t.Run("invalid", func(t *testing.T) {
+		vars := map[string]string{"DB_PORT": "-1", "HOSTS": "a;foo.com", "TTL": "1h"}
+		_, err := env.Load(envSchema, env.Separator(";"), env.Lookup(func(name string) (string, bool) {
+			value, ok := vars[name]
+			return value, ok
+		}))
+		assert.EqualError(t, err, `DB_HTTP_USER: required
+DB_PORT: must be between 1 and 65535
+HOSTS: 0: must be at least 3 characters long
+SERVICE_ID: required`)
+
+		var envErr env.Error
+		require.ErrorAs(t, err, &envErr)
+		assert.Equal(t, ecto.ListError{"required"}, envErr["SERVICE_ID"])
+	})
+
+	t.Run("decimal", func(t *testing.T) {
+		t.Setenv("APP_REPLICA_PORT", "08080")
+		cfg, err := env.Load(envSchema, env.Prefix("APP"))
+		require.NoError(t, err)
+		assert.Equal(t, 8080, cfg.Replica.Port)
+
+		for _, port := range []string{"0x10", "1_000"} {
+			t.Setenv("APP_REPLICA_PORT", port)
+			_, err := env.Load(envSchema, env.Prefix("APP"))
+			assert.EqualError(t, err, `APP_REPLICA_PORT: invalid int "`+port+`"`)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		t.Setenv("APP_TTL", "1 hour")
+		t.Setenv("APP_HOSTS", "")
+		t.Setenv("APP_REPLICA_PORT", "x")
+		_, err := env.Load(envSchema, env.Prefix("APP"))
+		assert.EqualError(t, err, `APP_REPLICA_PORT: invalid int "x"
+APP_TTL: invalid time.Duration "1 hour"`)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		type Config struct{ Ports map[string]int }
+		_, err := env.Load(ecto.Struct[Config](nil))
+		assert.ErrorContains(t, err, "unsupported type map[string]int")
+	})
+}
@@ -0,0 +1,64 @@
+package finance
+
+import (
+	"slices"
+	"strconv"
+)
+
+// Brand of payment card
+type Brand string
+
+const (
+	Visa       Brand = "Visa"
+	Mastercard Brand = "Mastercard"
+	Amex       Brand = "American Express"
+	Discover   Brand = "Discover"
+	JCB        Brand = "JCB"
+	DinersClub Brand = "Diners Club"
+	UnionPay   Brand = "UnionPay"
+	Maestro    Brand = "Maestro"
+	Mir        Brand = "Mir"
+)
+
+type brandRange struct {
+	brand    Brand
+	from, to int // Inclusive range of IIN prefixes of the same number of digits
+	lengths  []int
+}
+
+// brandRanges are ordered from specific prefixes to general ones
+var brandRanges = []brandRange{
+	{Mir, 2200, 2204, []int{16, 17, 18, 19}},
+	{Mastercard, 2221, 2720, []int{16}},
+	{Mastercard, 51, 55, []int{16}},
+	{Visa, 4, 4, []int{13, 16, 19}},
+	{Amex, 34, 34, []int{15}},
+	{Amex, 37, 37, []int{15}},
+	{JCB, 3528, 3589, []int{16, 17, 18, 19}},
+	{DinersClub, 300, 305, []int{14, 15, 16, 17, 18, 19}},
+	{DinersClub, 36, 36, []int{14, 15, 16, 17, 18, 19}},
+	{DinersClub, 38, 39, []int{16, 17, 18, 19}},
+	{Discover, 6011, 6011, []int{16, 17, 18, 19}},
+	{Discover, 622126, 622925, []int{16, 17, 18, 19}},
+	{Discover, 644, 649, []int{16, 17, 18, 19}},
+	{Discover, 65, 65, []int{16, 17, 18, 19}},
+	{UnionPay, 62, 62, []int{16, 17, 18, 19}},
+	{Maestro, 50, 50, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+	{Maestro, 56, 69, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+}
+
+// DetectBrand returns brand of payment card number by its prefix (IIN) and length. Empty if brand is unknown
+func DetectBrand(value string) Brand {
+	pan := normalizePAN(value)
+	for _, r := range brandRanges {
+		digits := len(strconv.Itoa(r.from))
+		if len(pan) < digits || !slices.Contains(r.lengths, len(pan)) {
+			continue
+		}
+		prefix, err := strconv.Atoi(pan[:digits])
+		if err == nil && prefix >= r.from && prefix <= r.to {
+			return r.brand
+		}
+	}
+	return ""
+}
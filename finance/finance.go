@@ -0,0 +1,164 @@
+package finance
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/egsam98/errors"
+	country "github.com/mikekonan/go-countries"
+	"github.com/samber/lo"
+
+	"github.com/egsam98/ecto"
+)
+
+// ibanLengths is a length of IBAN per country according to SWIFT IBAN registry
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BI": 27,
+	"BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28,
+	"EE": 20, "EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23,
+	"GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "LY": 25,
+	"MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18,
+	"NO": 15, "OM": 23, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33,
+	"SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+var big97 = big.NewInt(97)
+
+// IBAN validates International Bank Account Number: country, its length and mod-97 checksum (ISO 13616).
+// Spaces are allowed to accept print format, ex. "DE89 3704 0044 0532 0130 00"
+func IBAN() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "finance.IBAN",
+		Error: "invalid IBAN",
+		Func: func(v *string) bool {
+			iban := strings.ReplaceAll(*v, " ", "")
+			if len(iban) < 4 || len(iban) != ibanLengths[iban[:2]] || !isUpperAlnum(iban) {
+				return false
+			}
+
+			// Move country code and check digits to the end, replace letters with numbers (A = 10, ..., Z = 35)
+			var digits strings.Builder
+			for _, r := range iban[4:] + iban[:4] {
+				if r >= 'A' {
+					digits.WriteString(strconv.Itoa(int(r-'A') + 10))
+				} else {
+					digits.WriteRune(r)
+				}
+			}
+			num, _ := new(big.Int).SetString(digits.String(), 10)
+			return new(big.Int).Mod(num, big97).Int64() == 1
+		},
+	}
+}
+
+// IBANCountry restricts IBAN to be issued in one of countries (ISO-3166 alpha-2). Panics if country code is invalid
+func IBANCountry(codes ...string) ecto.Test[string] {
+	codes = lo.Map(codes, func(code string, _ int) string {
+		c, ok := country.ByAlpha2CodeStr(code)
+		if !ok {
+			panic(errors.Errorf("%q: invalid ISO-3166 country code", code))
+		}
+		return c.Alpha2CodeStr()
+	})
+
+	set := lo.Keyify(codes)
+	return ecto.Test[string]{
+		Code:   "finance.IBANCountry",
+		Params: map[string]any{"codes": codes},
+		Error:  ecto.Errorf("IBAN country must be one of %v", codes),
+		Func:   func(v *string) bool { return len(*v) >= 2 && lo.HasKey(set, (*v)[:2]) },
+	}
+}
+
+// BIC validates Business Identifier Code (SWIFT code, ISO 9362): 4 letters of institution,
+// ISO-3166 country code, 2 alphanumerics of location and optional 3 alphanumerics of branch
+func BIC() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "finance.BIC",
+		Error: "invalid BIC",
+		Func: func(v *string) bool {
+			bic := *v
+			if (len(bic) != 8 && len(bic) != 11) || !isUpperAlnum(bic) {
+				return false
+			}
+			if strings.ContainsAny(bic[:6], "0123456789") {
+				return false
+			}
+			_, ok := country.ByAlpha2CodeStr(bic[4:6])
+			return ok
+		},
+	}
+}
+
+// CardNumber validates payment card number (PAN): 12-19 digits and Luhn checksum.
+// Spaces and dashes are allowed to accept print format, ex. "4111 1111 1111 1111"
+func CardNumber() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "finance.CardNumber",
+		Error: "invalid card number",
+		Func: func(v *string) bool {
+			pan := normalizePAN(*v)
+			return len(pan) >= 12 && len(pan) <= 19 && luhn(pan)
+		},
+	}
+}
+
+// CardBrand restricts payment card number to be issued by one of brands (see DetectBrand)
+func CardBrand(brands ...Brand) ecto.Test[string] {
+	set := lo.Keyify(brands)
+	return ecto.Test[string]{
+		Code:   "finance.CardBrand",
+		Params: map[string]any{"brands": brands},
+		Error:  ecto.Errorf("card brand must be one of %v", brands),
+		Func:   func(v *string) bool { return lo.HasKey(set, DetectBrand(*v)) },
+	}
+}
+
+// MaskPAN hides payment card number except for first 6 and last 4 digits (PCI DSS), ex. "411111******1111".
+// Numbers shorter than 13 digits keep last 4 ones only. Use it to log or report card numbers
+func MaskPAN(value string) string {
+	pan := normalizePAN(value)
+	if len(pan) <= 4 {
+		return strings.Repeat("*", len(pan))
+	}
+
+	var head int
+	if len(pan) >= 13 {
+		head = 6
+	}
+	return pan[:head] + strings.Repeat("*", len(pan)-head-4) + pan[len(pan)-4:]
+}
+
+func normalizePAN(value string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(value)
+}
+
+func luhn(pan string) bool {
+	var sum int
+	for i := range len(pan) {
+		digit := int(pan[len(pan)-1-i] - '0')
+		if digit < 0 || digit > 9 {
+			return false
+		}
+		if i%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+func isUpperAlnum(s string) bool {
+	for i := range len(s) {
+		if c := s[i]; (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,192 @@
+package ecto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/egsam98/ecto/finance"
+)
+
+func TestIBAN(t *testing.T) {
+	test := finance.IBAN()
+	// Examples of SWIFT IBAN registry for every length of IBAN
+	for _, iban := range []string{
+		"NO9386011117947",
+		"BE68539007547034",
+		"NL91ABNA0417164300",
+		"MK07250120000058984",
+		"AT611904300234573201",
+		"CH9300762011623852957",
+		"DE89370400440532013000",
+		"AE070331234567890123456",
+		"ES9121000418450200051332",
+		"PT50000201231234567890154",
+		"TR330006100519786457841326",
+		"FR1420041010050500013M02606",
+		"PL61109010140000071219812874",
+		"BR1800360305000010009795493C1",
+		"KW81CBKU0000000000001234560101",
+		"MT84MALT011000012345MTLCAST001S",
+		"LC55HEMM000100010012001200023015",
+		"RU0304452522540817810538091310419",
+	} {
+		assert.Nil(t, test.Run(&iban), iban)
+
+		// Any single changed character breaks checksum
+		last := iban[len(iban)-1]
+		bad := iban[:len(iban)-1] + string(last+1)
+		if last == '9' {
+			bad = iban[:len(iban)-1] + "0"
+		}
+		assert.NotNil(t, test.Run(&bad), bad)
+	}
+
+	for _, iban := range []string{
+		"DE89 3704 0044 0532 0130 00",
+		"GB29NWBK60161331926819",
+	} {
+		assert.Nil(t, test.Run(&iban), iban)
+	}
+	for _, iban := range []string{
+		"",
+		"DE",
+		"DE8937040044053201300",   // Short
+		"DE890370400440532013000", // Long
+		"de89370400440532013000",
+		"DE89-3704-0044-0532-0130-00",
+		"XX89370400440532013000",
+	} {
+		assert.NotNil(t, test.Run(&iban), iban)
+	}
+}
+
+func TestIBANCountry(t *testing.T) {
+	test := finance.IBANCountry("de", "FR")
+	for iban, valid := range map[string]bool{
+		"DE89370400440532013000":      true,
+		"FR1420041010050500013M02606": true,
+		"GB29NWBK60161331926819":      false,
+		"D":                           false,
+	} {
+		assert.Equal(t, valid, test.Run(&iban) == nil, iban)
+	}
+	assert.Equal(t, map[string]any{"codes": []string{"DE", "FR"}}, test.Params)
+	assert.Panics(t, func() { finance.IBANCountry("XX") })
+}
+
+func TestBIC(t *testing.T) {
+	test := finance.BIC()
+	for bic, valid := range map[string]bool{
+		"DEUTDEFF":     true,
+		"DEUTDEFF500":  true,
+		"NEDSZAJJXXX":  true,
+		"DEUTDEF":      false, // 7
+		"DEUTDEFF5":    false, // 9
+		"DEUTDEFF50":   false, // 10
+		"DEUTDEFF5000": false, // 12
+		"deutdeff":     false,
+		"DEU1DEFF":     false, // Digit in institution
+		"DEUTXXFF":     false, // Unknown country
+		"DEUTDE-F":     false,
+	} {
+		assert.Equal(t, valid, test.Run(&bic) == nil, bic)
+	}
+}
+
+func TestCardNumber(t *testing.T) {
+	test := finance.CardNumber()
+	for pan, valid := range map[string]bool{
+		"4111111111111111":     true,
+		"4111 1111 1111 1111":  true,
+		"4111-1111-1111-1111":  true,
+		"378282246310005":      true,
+		"4222222222222":        true,
+		"4000000000000000006":  true,
+		"500000000009":         true,  // 12 digits
+		"4111111111111112":     false, // Luhn
+		"4111111111111121":     false, // Luhn of swapped digits
+		"50000000009":          false, // 11 digits
+		"40000000000000000006": false, // 20 digits
+		"4111.1111.1111.1111":  false,
+		"4111a11111111111":     false,
+		"":                     false,
+	} {
+		assert.Equal(t, valid, test.Run(&pan) == nil, pan)
+	}
+}
+
+func TestDetectBrand(t *testing.T) {
+	for pan, brand := range map[string]finance.Brand{
+		"4111111111111111":    finance.Visa,
+		"4222222222222":       finance.Visa,
+		"4000000000000000006": finance.Visa,
+		"411111111111111":     "", // Visa of 15 digits
+		"5100000000000000":    finance.Mastercard,
+		"5555555555554444":    finance.Mastercard,
+		"2221000000000000":    finance.Mastercard,
+		"2720999999999999":    finance.Mastercard,
+		"2220999999999999":    "",
+		"2721000000000000":    "",
+		"2200000000000004":    finance.Mir,
+		"2204999999999999":    finance.Mir,
+		"2205000000000000":    "",
+		"340000000000000":     finance.Amex,
+		"378282246310005":     finance.Amex,
+		"3782822463100050":    "", // Amex of 16 digits
+		"3528000000000000":    finance.JCB,
+		"3589999999999999":    finance.JCB,
+		"3527999999999999":    "",
+		"3590000000000000":    "",
+		"30000000000000":      finance.DinersClub,
+		"30569309025904":      finance.DinersClub,
+		"30600000000000":      "",
+		"36000000000000":      finance.DinersClub,
+		"3800000000000000":    finance.DinersClub,
+		"6011111111111117":    finance.Discover,
+		"6221260000000000":    finance.Discover,
+		"6229250000000000":    finance.Discover,
+		"6221250000000000":    finance.UnionPay,
+		"6229260000000000":    finance.UnionPay,
+		"6440000000000000":    finance.Discover,
+		"6500000000000000":    finance.Discover,
+		"6200000000000005":    finance.UnionPay,
+		"5000000000000000":    finance.Maestro,
+		"6759649826438453":    finance.Maestro,
+		"6430000000000000":    finance.Maestro,
+		"500000000009":        finance.Maestro,
+		"5600 0000 0000 0000": finance.Maestro,
+		"1234567890123456":    "",
+		"":                    "",
+	} {
+		assert.Equal(t, brand, finance.DetectBrand(pan), pan)
+	}
+}
+
+func TestCardBrand(t *testing.T) {
+	test := finance.CardBrand(finance.Visa, finance.Mastercard)
+	for pan, valid := range map[string]bool{
+		"4111111111111111": true,
+		"2223003122003222": true,
+		"378282246310005":  false,
+		"1234567890123456": false,
+	} {
+		assert.Equal(t, valid, test.Run(&pan) == nil, pan)
+	}
+}
+
+func TestMaskPAN(t *testing.T) {
+	for pan, masked := range map[string]string{
+		"4111111111111111":    "411111******1111",
+		"4111 1111 1111 1111": "411111******1111",
+		"4111-1111-1111-1111": "411111******1111",
+		"4000000000000000006": "400000*********0006",
+		"4222222222222":       "422222***2222",
+		"500000000009":        "********0009",
+		"12345":               "*2345",
+		"1234":                "****",
+		"":                    "",
+	} {
+		assert.Equal(t, masked, finance.MaskPAN(pan), pan)
+	}
+}
@@ -7,29 +7,45 @@ import (
 	"github.com/egsam98/ecto"
 )
 
+// significantDigits is a number of decimal digits float64 is guaranteed to represent exactly. MaxPrecision rounds
+// values to it, so binary representation errors (ex. 0.1+0.2 == 0.30000000000000004) are ignored
+const significantDigits = 15
+
 // Min restricts value with lower inclusive bound
 func Min(value float64) ecto.Test[float64] {
 	return ecto.Test[float64]{
-		Error: ecto.Errorf("must be %s minimum", strconv.FormatFloat(value, 'f', -1, 64)),
-		Func:  func(v *float64) bool { return *v >= value },
+		Code:   "floats.Min",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Errorf("must be %s minimum", strconv.FormatFloat(value, 'f', -1, 64)),
+		Func:   func(v *float64) bool { return *v >= value },
 	}
 }
 
 // Max restricts value with upper inclusive bound
 func Max(value float64) ecto.Test[float64] {
 	return ecto.Test[float64]{
-		Error: ecto.Errorf("must be %s maximum", strconv.FormatFloat(value, 'f', -1, 64)),
-		Func:  func(v *float64) bool { return *v <= value },
+		Code:   "floats.Max",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Errorf("must be %s maximum", strconv.FormatFloat(value, 'f', -1, 64)),
+		Func:   func(v *float64) bool { return *v <= value },
 	}
 }
 
-// MaxPrecision restricts precision/scale/fractional number of digits
+// MaxPrecision restricts precision/scale/fractional number of digits.
+// For exact validation of textual input (json.Number, string) use ecto.DecimalFrom with ecto/decimals tests
 func MaxPrecision(value uint) ecto.Test[float64] {
 	return ecto.Test[float64]{
-		Error: ecto.Errorf("has more than %d precision digits", value),
+		Code:   "floats.MaxPrecision",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Errorf("has more than %d precision digits", value),
 		Func: func(v *float64) bool {
-			_, prec, _ := strings.Cut(strconv.FormatFloat(*v, 'f', -1, 64), ".")
+			_, prec, _ := strings.Cut(strconv.FormatFloat(normalize(*v), 'f', -1, 64), ".")
 			return uint(len(prec)) <= value
 		},
 	}
 }
+
+func normalize(v float64) float64 {
+	f, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'g', significantDigits, 64), 64)
+	return f
+}
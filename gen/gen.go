@@ -0,0 +1,215 @@
+// Package gen generates random values of struct schemas for fuzz and property testing: valid ones and ones
+// violating exactly one rule. Values are guided by built-in tests (see ecto.TestNode codes): string lengths,
+// regexes, formats (email, URL, IP etc.), numeric and time bounds, ecto.OneOf, unique slices. Every value is
+// checked by the schema, so custom tests are respected by retries:
+//
+//	func FuzzCreateUser(f *testing.F) {
+//		gen.New(userSchema).Fuzz(f, func(t *testing.T, user User, violation *gen.Violation) {
+//			err := handler.CreateUser(ctx, user)
+//			if violation == nil {
+//				require.NoError(t, err)
+//			} else {
+//				require.EqualError(t, err, violation.Err().Error())
+//			}
+//		})
+//	}
+package gen
+
+import (
+	"context"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/egsam98/errors"
+	"github.com/samber/lo"
+
+	"github.com/egsam98/ecto"
+)
+
+// Required is a code of violation leaving required value zero
+const Required = "ecto.Required"
+
+// ErrNoRules is returned by Invalid if generated values have no rules to violate
+var ErrNoRules = errors.New("no rules to violate")
+
+type Opt func(*config)
+
+// Context passes ctx to schema checking generated values
+func Context(ctx context.Context) Opt {
+	return func(cfg *config) { cfg.ctx = ctx }
+}
+
+// MaxDepth bounds nesting of recursive schemas (3 by default)
+func MaxDepth(depth uint) Opt {
+	return func(cfg *config) { cfg.maxDepth = int(depth) }
+}
+
+// MaxAttempts bounds retries of generation rejected by schema (100 by default)
+func MaxAttempts(attempts uint) Opt {
+	return func(cfg *config) { cfg.maxAttempts = int(attempts) }
+}
+
+type config struct {
+	ctx                   context.Context
+	maxDepth, maxAttempts int
+}
+
+// Generator of values of struct type T
+type Generator[T any] struct {
+	cfg    config
+	schema ecto.StructSchema[T]
+	node   ecto.Node
+}
+
+func New[T any](schema ecto.StructSchema[T], opts ...Opt) *Generator[T] {
+	cfg := config{ctx: context.Background(), maxDepth: 3, maxAttempts: 100}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return &Generator[T]{cfg: cfg, schema: schema, node: ecto.Inspect(schema)}
+}
+
+// Valid generates value accepted by schema
+func (g *Generator[T]) Valid(rnd *rand.Rand) (T, error) {
+	var lastErr error
+	for range g.cfg.maxAttempts {
+		var value T
+		gen := generator{rnd: rnd, maxDepth: g.cfg.maxDepth}
+		gen.value(reflect.ValueOf(&value).Elem(), &g.node)
+		if lastErr = g.check(value); lastErr == nil {
+			return value, nil
+		}
+	}
+	var zero T
+	return zero, errors.Wrapf(lastErr, "generate valid %T in %d attempts", zero, g.cfg.maxAttempts)
+}
+
+// Invalid generates value violating exactly one rule of schema, i.e. processing fails with Violation.Err.
+// Returns ErrNoRules if none of generated values can be mutated
+func (g *Generator[T]) Invalid(rnd *rand.Rand) (T, Violation, error) {
+	var zero T
+	var mutable bool
+	for range g.cfg.maxAttempts {
+		valid, err := g.Valid(rnd)
+		if err != nil {
+			return zero, Violation{}, err
+		}
+
+		gen := generator{rnd: rnd, maxDepth: g.cfg.maxDepth}
+		// Tests are picked uniformly, so ones of many slice elements don't crowd out the rest
+		groups := lo.Values(lo.GroupBy(gen.mutations(reflect.ValueOf(&valid).Elem(), &g.node), func(m mutation) string {
+			return m.violation.Test.String()
+		}))
+		slices.SortFunc(groups, func(a, b []mutation) int {
+			return strings.Compare(a[0].violation.Test.String(), b[0].violation.Test.String())
+		})
+		mutable = mutable || len(groups) > 0
+		rnd.Shuffle(len(groups), func(i, j int) { groups[i], groups[j] = groups[j], groups[i] })
+		for _, group := range groups {
+			mutation := group[rnd.IntN(len(group))]
+			value := clone(valid)
+			mutation.apply(reflect.ValueOf(&value).Elem())
+			if err := g.check(value); err != nil && err.Error() == mutation.violation.Err().Error() {
+				return value, mutation.violation, nil
+			}
+		}
+	}
+	if !mutable {
+		return zero, Violation{}, ErrNoRules
+	}
+	return zero, Violation{}, errors.Errorf("generate invalid %T in %d attempts", zero, g.cfg.maxAttempts)
+}
+
+// Fuzz runs fn for valid values and ones violating single rule generated from fuzzed seeds.
+// Violation is nil for valid values. Invalid seeds are skipped for schema having no rules to violate
+func (g *Generator[T]) Fuzz(f *testing.F, fn func(t *testing.T, value T, violation *Violation)) {
+	for seed := range uint64(4) {
+		f.Add(seed, false)
+		f.Add(seed, true)
+	}
+	f.Fuzz(func(t *testing.T, seed uint64, invalid bool) {
+		rnd := rand.New(rand.NewPCG(seed, seed))
+		if !invalid {
+			value, err := g.Valid(rnd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fn(t, value, nil)
+			return
+		}
+
+		value, violation, err := g.Invalid(rnd)
+		if err == ErrNoRules {
+			t.Skip(err)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		fn(t, value, &violation)
+	})
+}
+
+// check processes copy of value, so generated one isn't modified by transforms and defaults
+func (g *Generator[T]) check(value T) error {
+	value = clone(value)
+	return g.schema.ProcessContext(g.cfg.ctx, &value)
+}
+
+// Violation of schema rule
+type Violation struct {
+	// Path consists of keys of MapError: field tags and slice indexes
+	Path []string
+	// Test is violated test. It's code is Required for zero value of required schema
+	Test ecto.TestNode
+}
+
+// Err returns error of processing value with violation
+func (v Violation) Err() error {
+	var err error = ecto.ListError{v.Test.Error}
+	for _, key := range slices.Backward(v.Path) {
+		err = ecto.MapError{key: err}
+	}
+	return err
+}
+
+func (v Violation) String() string {
+	return strings.Join(v.Path, ".") + ": " + v.Test.String()
+}
+
+// clone deeply copies slices and pointers of value
+func clone[T any](value T) T {
+	var res T
+	cloneValue(reflect.ValueOf(&res).Elem(), reflect.ValueOf(value))
+	return res
+}
+
+func cloneValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if !src.IsNil() {
+			dst.Set(reflect.New(src.Type().Elem()))
+			cloneValue(dst.Elem(), src.Elem())
+		}
+	case reflect.Slice:
+		if !src.IsNil() {
+			dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+			for i := range src.Len() {
+				cloneValue(dst.Index(i), src.Index(i))
+			}
+		}
+	case reflect.Struct:
+		dst.Set(src)
+		for i := range src.NumField() {
+			if dst.Field(i).CanSet() {
+				cloneValue(dst.Field(i), src.Field(i))
+			}
+		}
+	default:
+		dst.Set(src)
+	}
+}
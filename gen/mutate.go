@@ -0,0 +1,367 @@
+package gen
+
+import (
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+
+	"github.com/egsam98/ecto"
+)
+
+// invalidString violates format tests (see samples)
+const invalidString = "not valid!"
+
+// mutation replaces value at path with one violating single test
+type mutation struct {
+	violation Violation
+	// nav leads from root value to mutated one
+	nav   []func(reflect.Value) reflect.Value
+	value reflect.Value
+}
+
+func (m mutation) apply(root reflect.Value) {
+	for _, step := range m.nav {
+		root = step(root)
+	}
+	root.Set(m.value)
+}
+
+// mutations lists candidates violating single test of valid value
+func (g *generator) mutations(rv reflect.Value, node *ecto.Node) []mutation {
+	m := mutator{generator: g}
+	m.walk(rv, node, nil, nil)
+	return m.mutations
+}
+
+type mutator struct {
+	*generator
+	mutations []mutation
+}
+
+func (m *mutator) add(path []string, nav []func(reflect.Value) reflect.Value, test ecto.TestNode, value reflect.Value) {
+	m.mutations = append(m.mutations, mutation{
+		violation: Violation{Path: slices.Clone(path), Test: test},
+		nav:       slices.Clone(nav),
+		value:     value,
+	})
+}
+
+func (m *mutator) walk(rv reflect.Value, node *ecto.Node, path []string, nav []func(reflect.Value) reflect.Value) {
+	if node.Kind == ecto.KindLazy || node.Recursive {
+		if target := m.resolve(rv.Type(), node); target != nil {
+			m.walk(rv, target, path, nav)
+		}
+		return
+	}
+
+	switch node.Kind {
+	case ecto.KindStruct:
+		m.ancestors = append(m.ancestors, node)
+		defer func() { m.ancestors = m.ancestors[:len(m.ancestors)-1] }()
+		for _, field := range node.Fields {
+			m.walk(rv.FieldByName(field.Name), &field.Node, append(slices.Clip(path), field.Tag),
+				append(slices.Clip(nav), func(rv reflect.Value) reflect.Value { return rv.FieldByName(field.Name) }))
+		}
+	case ecto.KindSlice:
+		for _, test := range node.Tests {
+			if value, ok := m.slice(rv, node.Elem, test); ok {
+				m.add(path, nav, test, value)
+			}
+		}
+		for i := range rv.Len() {
+			m.walk(rv.Index(i), node.Elem, append(slices.Clip(path), strconv.Itoa(i)),
+				append(slices.Clip(nav), func(rv reflect.Value) reflect.Value { return rv.Index(i) }))
+		}
+	case ecto.KindPtr:
+		if rv.IsNil() {
+			return
+		}
+		if node.Required {
+			m.add(path, nav, ecto.TestNode{Code: Required, Error: "required"}, reflect.Zero(rv.Type()))
+		}
+		m.walk(rv.Elem(), node.Elem, path, append(slices.Clip(nav), reflect.Value.Elem))
+	case ecto.KindAtomic:
+		if node.Required && !rv.IsZero() {
+			m.add(path, nav, ecto.TestNode{Code: Required, Error: "required"}, reflect.Zero(rv.Type()))
+		}
+		if node.Result != "" {
+			m.converted(rv, node, path, nav)
+			return
+		}
+		for _, test := range node.Tests {
+			if value, ok := m.scalar(rv, test); ok {
+				m.add(path, nav, test, value)
+			}
+		}
+	}
+}
+
+// converted violates tests of converting schema through its result parsed from input (see generator.converted)
+func (m *mutator) converted(rv reflect.Value, node *ecto.Node, path []string, nav []func(reflect.Value) reflect.Value) {
+	resultType, ok := resultTypes[node.Result]
+	if !ok {
+		return
+	}
+	result, ok := parseResult(rv, resultType)
+	if !ok {
+		return
+	}
+	for _, test := range node.Tests {
+		if value, ok := m.scalar(result, test); ok {
+			input := reflect.New(rv.Type()).Elem()
+			if formatResult(input, value) {
+				m.add(path, nav, test, input)
+			}
+		}
+	}
+}
+
+func (m *mutator) slice(rv reflect.Value, elem *ecto.Node, test ecto.TestNode) (reflect.Value, bool) {
+	length := toInt(test.Params["length"])
+	switch test.Code {
+	case "slices.Min":
+		if length == 0 || rv.Len() < length {
+			return reflect.Value{}, false
+		}
+		return rv.Slice(0, length-1), true
+	case "slices.Max", "slices.Len":
+		res := reflect.MakeSlice(rv.Type(), rv.Len(), length+1)
+		reflect.Copy(res, rv)
+		for res.Len() <= length {
+			value := reflect.New(rv.Type().Elem()).Elem()
+			m.value(value, elem)
+			res = reflect.Append(res, value)
+		}
+		return res, true
+	case "slices.UniqueBy":
+		if rv.Len() == 0 {
+			return reflect.Value{}, false
+		}
+		res := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len()+1)
+		reflect.Copy(res, rv)
+		return reflect.Append(res, res.Index(0)), true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+func (m *mutator) scalar(rv reflect.Value, test ecto.TestNode) (reflect.Value, bool) {
+	var value any
+	switch typ := rv.Type(); {
+	case typ == typeTime:
+		value = violateTime(rv.Interface().(time.Time), test)
+	case typ == typeUUID:
+		value = violateUUID(rv.Interface().(uuid.UUID), test)
+	case typ == typeDecimal:
+		value = violateDecimal(rv.Interface().(decimal.Decimal), test)
+	case typ.Kind() == reflect.String:
+		if s, ok := violateString(rv.String(), test); ok {
+			value = s
+		}
+	case rv.CanInt() || rv.CanUint():
+		if v, ok := violateInt(toInt64(rv.Interface()), test); ok && !overflows(rv, v) {
+			value = v
+		}
+	case rv.CanFloat():
+		if v, ok := violateFloat(rv.Float(), test); ok {
+			value = v
+		}
+	}
+	if value == nil {
+		return reflect.Value{}, false
+	}
+
+	res := reflect.New(rv.Type()).Elem()
+	switch value := value.(type) {
+	case string:
+		res.SetString(value)
+	case int64:
+		if res.CanInt() {
+			res.SetInt(value)
+		} else {
+			res.SetUint(uint64(value))
+		}
+	case float64:
+		res.SetFloat(value)
+	default:
+		res.Set(reflect.ValueOf(value))
+	}
+	return res, true
+}
+
+func violateString(s string, test ecto.TestNode) (string, bool) {
+	length := toInt(test.Params["length"])
+	switch test.Code {
+	case "strings.Min", "strings.MinBytes", "strings.MinGraphemes", "password.MinLength":
+		if length == 0 {
+			return "", false
+		}
+		return strings.Repeat("a", length-1), true
+	case "strings.Max", "strings.MaxBytes", "strings.MaxGraphemes", "strings.Len":
+		return s + strings.Repeat("a", max(1, length+1-len(s))), true
+	case "strings.Regex":
+		re, err := regexp.Compile(test.Params["regex"].(string))
+		if err != nil {
+			return "", false
+		}
+		for _, candidate := range []string{"!", s + "!", "!" + s, " ", "~~"} {
+			if !re.MatchString(candidate) {
+				return candidate, true
+			}
+		}
+		return "", false
+	case "ecto.OneOf":
+		return s + "_", true
+	case "strings.Trimmed":
+		return " " + s, true
+	case "strings.ASCII":
+		return s + "é", true
+	case "strings.Printable", "strings.NoControl":
+		return s + "\x07", true
+	case "password.RequireUpper":
+		return strings.ToLower(s), true
+	case "password.RequireLower":
+		return strings.ToUpper(s), true
+	case "password.RequireDigit":
+		return strings.Map(func(r rune) rune { return lo.Ternary(unicode.IsDigit(r), 'x', r) }, s), true
+	case "password.RequireSpecial":
+		return strings.Map(func(r rune) rune {
+			return lo.Ternary(unicode.IsLetter(r) || unicode.IsDigit(r), r, 'x')
+		}, s), true
+	case "strings.DateTime", "strings.Phone", "strings.PhoneCountry":
+		return invalidString, true
+	}
+	if samples[test.Code] != nil {
+		return invalidString, true
+	}
+	return "", false
+}
+
+func violateInt(v int64, test ecto.TestNode) (int64, bool) {
+	_, name, _ := strings.Cut(test.Code, ".")
+	value := toInt64(test.Params["value"])
+	switch {
+	case test.Code == "network.Port":
+		return 0, true
+	case name == "Min" || name == "MinDuration":
+		return value - 1, value > math.MinInt64
+	case name == "Max" || name == "MaxDuration":
+		return value + 1, value < math.MaxInt64
+	case name == "MinExclusive" || name == "MaxExclusive" || name == "NotEq":
+		return value, true
+	case name == "Eq":
+		return value + 1, value < math.MaxInt64
+	case name == "Between":
+		return toInt64(test.Params["min"]) - 1, toInt64(test.Params["min"]) > math.MinInt64
+	case name == "Positive" || name == "NonNegative":
+		return -1, true
+	case name == "MultipleOf":
+		return v + 1, toInt64(test.Params["step"]) > 1
+	case test.Code == "ecto.OneOf":
+		variants := reflect.ValueOf(test.Params["variants"])
+		res := int64(math.MinInt64)
+		for i := range variants.Len() {
+			res = max(res, toInt64(variants.Index(i).Interface()))
+		}
+		return res + 1, res < math.MaxInt64
+	}
+	return 0, false
+}
+
+func violateFloat(v float64, test ecto.TestNode) (float64, bool) {
+	switch _, name, _ := strings.Cut(test.Code, "."); name {
+	case "Min":
+		return toFloat(test.Params["value"]) - 1, true
+	case "Max":
+		return toFloat(test.Params["value"]) + 1, true
+	case "MaxPrecision":
+		return math.Trunc(v) + 1.0/3, toInt(test.Params["value"]) < 15
+	}
+	return 0, false
+}
+
+func violateDecimal(v decimal.Decimal, test ecto.TestNode) any {
+	value, _ := test.Params["value"].(decimal.Decimal)
+	switch test.Code {
+	case "decimals.Min":
+		return value.Sub(decimal.NewFromInt(1))
+	case "decimals.Max":
+		return value.Add(decimal.NewFromInt(1))
+	case "decimals.Positive":
+		return decimal.NewFromInt(-1)
+	case "decimals.MaxScale":
+		return v.Add(decimal.New(1, -int32(toInt(test.Params["value"]))-1))
+	case "decimals.MultipleOf":
+		step, _ := test.Params["step"].(decimal.Decimal)
+		return v.Add(step.Div(decimal.NewFromInt(2)))
+	}
+	return nil
+}
+
+func violateTime(v time.Time, test ecto.TestNode) any {
+	now := time.Now().UTC().Truncate(time.Second)
+	switch test.Code {
+	case "times.Before", "times.After":
+		return test.Params["value"]
+	case "times.Between":
+		return test.Params["from"].(time.Time).Add(-time.Second)
+	// Shifts by weeks keep weekday satisfying times.Weekday
+	case "times.NotInFuture":
+		for !v.After(now) {
+			v = v.AddDate(0, 0, 7)
+		}
+		return v
+	case "times.NotInPast":
+		for !v.Before(now) {
+			v = v.AddDate(0, 0, -7)
+		}
+		return v
+	case "times.MinAge":
+		return now
+	case "times.MaxAge":
+		return now.AddDate(-toInt(test.Params["years"])-2, 0, 0)
+	case "times.Weekday":
+		days, _ := test.Params["days"].([]time.Weekday)
+		if len(days) == 7 {
+			return nil
+		}
+		for slices.Contains(days, v.Weekday()) {
+			v = v.AddDate(0, 0, 1)
+		}
+		return v
+	}
+	return nil
+}
+
+func violateUUID(v uuid.UUID, test ecto.TestNode) any {
+	switch test.Code {
+	case "uuids.Version":
+		versions, _ := test.Params["versions"].([]uuid.Version)
+		for _, version := range []uuid.Version{4, 7, 1} {
+			if !slices.Contains(versions, version) {
+				v[6] = v[6]&0x0f | byte(version)<<4
+				return v
+			}
+		}
+	case "uuids.NotNil":
+		return uuid.Nil
+	}
+	return nil
+}
+
+// overflows reports whether v doesn't fit integer type of rv
+func overflows(rv reflect.Value, v int64) bool {
+	if rv.CanUint() {
+		return v < 0 || rv.OverflowUint(uint64(v))
+	}
+	return rv.OverflowInt(v)
+}
@@ -0,0 +1,92 @@
+package gen
+
+import (
+	"math/rand/v2"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+
+	"github.com/egsam98/errors"
+)
+
+// maxRepeat bounds unlimited repetitions (*, +, {n,}) of regex
+const maxRepeat = 8
+
+// Regex generates strings matching regular expression in Go syntax
+type Regex struct {
+	re *syntax.Regexp
+}
+
+// NewRegex parses regular expression, ex. regexp.Regexp.String() of strings.Regex test
+func NewRegex(expr string) (*Regex, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse regex %s", expr)
+	}
+	return &Regex{re: re.Simplify()}, nil
+}
+
+// Generate returns random string matching regex. Anchors and word boundaries are ignored
+func (r *Regex) Generate(rnd *rand.Rand) string {
+	var b strings.Builder
+	generateRegex(rnd, &b, r.re)
+	return b.String()
+}
+
+func generateRegex(rnd *rand.Rand, b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && rnd.IntN(2) == 0 {
+				r = unicode.SimpleFold(r)
+			}
+			b.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		b.WriteRune(randomRune(rnd, re.Rune))
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		b.WriteRune(rune(alnum[rnd.IntN(len(alnum))]))
+	case syntax.OpCapture:
+		generateRegex(rnd, b, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			generateRegex(rnd, b, sub)
+		}
+	case syntax.OpAlternate:
+		generateRegex(rnd, b, re.Sub[rnd.IntN(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		low, high := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			low, high = 0, -1
+		case syntax.OpPlus:
+			low, high = 1, -1
+		case syntax.OpQuest:
+			low, high = 0, 1
+		}
+		if high < 0 {
+			high = low + maxRepeat
+		}
+		for range low + rnd.IntN(high-low+1) {
+			generateRegex(rnd, b, re.Sub[0])
+		}
+	}
+}
+
+// randomRune picks rune from class of pairs of inclusive ranges preferring printable ASCII ones
+func randomRune(rnd *rand.Rand, class []rune) rune {
+	var ascii []rune
+	for i := 0; i < len(class); i += 2 {
+		low, high := max(class[i], ' '), min(class[i+1], '~')
+		if low <= high {
+			ascii = append(ascii, low, high)
+		}
+	}
+	if len(ascii) > 0 {
+		class = ascii
+	}
+
+	i := rnd.IntN(len(class)/2) * 2
+	low, high := class[i], class[i+1]
+	return low + rune(rnd.Int64N(int64(high-low)+1))
+}
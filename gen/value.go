@@ -0,0 +1,593 @@
+package gen
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/netip"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nyaruka/phonenumbers"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+
+	"github.com/egsam98/ecto"
+)
+
+const (
+	alnum = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// maxExtra bounds lengths of strings and ranges of numbers if schema doesn't
+	maxExtra = 16
+	// maxItems bounds lengths of slices if schema doesn't, keeping recursive values small
+	maxItems = 4
+	maxRange = 1000
+)
+
+var (
+	typeTime    = reflect.TypeFor[time.Time]()
+	typeUUID    = reflect.TypeFor[uuid.UUID]()
+	typeDecimal = reflect.TypeFor[decimal.Decimal]()
+	typeAddr    = reflect.TypeFor[netip.Addr]()
+)
+
+// samples of strings satisfying format tests
+var samples = map[string][]string{
+	"strings.Email":      {"user@example.com", "john.doe@mail.example.org"},
+	"strings.URLWith":    {"https://example.com", "https://example.org/path?q=1"},
+	"strings.IP":         {"192.0.2.1", "2001:db8::1"},
+	"strings.Currency":   {"USD", "EUR", "JPY"},
+	"strings.Lang":       {"en", "de", "ru"},
+	"strings.Country":    {"US", "DE", "JP"},
+	"strings.Base64":     {"aGVsbG8=", "d29ybGQ="},
+	"network.IPv4":       {"192.0.2.1", "198.51.100.7"},
+	"network.IPv6":       {"2001:db8::1", "fe80::1"},
+	"network.CIDR":       {"192.0.2.0/24", "2001:db8::/32"},
+	"network.Hostname":   {"example.com", "api.example.org"},
+	"network.FQDN":       {"example.com", "api.example.org"},
+	"network.MAC":        {"00:00:5e:00:53:01", "02:42:ac:11:00:02"},
+	"network.HostPort":   {"example.com:8080", "192.0.2.1:443"},
+	"finance.IBAN":       {"DE89370400440532013000", "GB82WEST12345698765432"},
+	"finance.BIC":        {"DEUTDEFF", "NWBKGB2L"},
+	"finance.CardNumber": {"4111111111111111", "5555555555554444"},
+}
+
+// resultTypes of converting atomic schemas (see ecto.AtomicFrom) generated and formatted as input
+var resultTypes = lo.SliceToMap([]reflect.Type{
+	reflect.TypeFor[int](), reflect.TypeFor[int64](), reflect.TypeFor[float64](), typeTime, typeUUID, typeDecimal,
+	reflect.TypeFor[time.Duration](),
+}, func(typ reflect.Type) (string, reflect.Type) { return typ.String(), typ })
+
+// generator walks schema tree described by ecto.Node along with value
+type generator struct {
+	rnd      *rand.Rand
+	maxDepth int
+	// depth of recursive schemas
+	depth     int
+	ancestors []*ecto.Node
+}
+
+func (g *generator) value(rv reflect.Value, node *ecto.Node) {
+	if node.Kind == ecto.KindLazy || node.Recursive {
+		target := g.resolve(rv.Type(), node)
+		if target == nil || g.depth >= g.maxDepth {
+			return
+		}
+		g.depth++
+		defer func() { g.depth-- }()
+		g.value(rv, target)
+		return
+	}
+
+	switch node.Kind {
+	case ecto.KindStruct:
+		g.ancestors = append(g.ancestors, node)
+		defer func() { g.ancestors = g.ancestors[:len(g.ancestors)-1] }()
+		for _, field := range node.Fields {
+			g.value(rv.FieldByName(field.Name), &field.Node)
+		}
+	case ecto.KindSlice:
+		low, high := lengthBounds(node.Tests, "slices", false, maxItems)
+		if g.exhausted(node.Elem) {
+			high = low
+		}
+		unique := hasCode(node.Tests, "slices.UniqueBy")
+		slice := reflect.MakeSlice(rv.Type(), 0, high)
+		for range low + g.rnd.IntN(high-low+1) {
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			for range 10 {
+				elem.SetZero()
+				g.value(elem, node.Elem)
+				if !unique || !slices.ContainsFunc(toSlice(slice), func(v reflect.Value) bool {
+					return reflect.DeepEqual(v.Interface(), elem.Interface())
+				}) {
+					break
+				}
+			}
+			slice = reflect.Append(slice, elem)
+		}
+		rv.Set(slice)
+	case ecto.KindPtr:
+		if !node.Required && (g.rnd.IntN(4) == 0 || g.exhausted(node.Elem)) {
+			return
+		}
+		ptr := reflect.New(rv.Type().Elem())
+		g.value(ptr.Elem(), node.Elem)
+		rv.Set(ptr)
+	case ecto.KindAtomic:
+		// Zero value is valid if it's not tested or replaced by default
+		if !node.Required && (len(node.Tests) == 0 || node.Default != nil) && g.rnd.IntN(4) == 0 {
+			return
+		}
+		if node.Result == "" {
+			g.scalar(rv, node.Tests, node.Required)
+			return
+		}
+		g.converted(rv, node)
+	}
+}
+
+// resolve returns schema of lazy one or the nearest struct schema of type being generated up the tree
+func (g *generator) resolve(typ reflect.Type, node *ecto.Node) *ecto.Node {
+	if !node.Recursive {
+		return node.Elem
+	}
+	for _, ancestor := range slices.Backward(g.ancestors) {
+		if ancestor.Type == typ.String() {
+			return ancestor
+		}
+	}
+	return nil
+}
+
+// exhausted reports whether elements of slice or pointee can't be generated due to depth limit
+func (g *generator) exhausted(node *ecto.Node) bool {
+	return (node.Kind == ecto.KindLazy || node.Recursive) && g.depth >= g.maxDepth
+}
+
+// converted generates value of converting schema as formatted result
+func (g *generator) converted(rv reflect.Value, node *ecto.Node) {
+	resultType, ok := resultTypes[node.Result]
+	if !ok {
+		return
+	}
+	result := reflect.New(resultType).Elem()
+	g.scalar(result, node.Tests, node.Required)
+	formatResult(rv, result)
+}
+
+// formatResult sets input rv to value of converting schema result. Reports false if types aren't compatible
+func formatResult(rv, result reflect.Value) bool {
+	switch {
+	case rv.Kind() == reflect.String && result.Type() == typeTime:
+		rv.SetString(result.Interface().(time.Time).Format(time.RFC3339))
+	case rv.Kind() == reflect.String:
+		rv.SetString(fmt.Sprint(result.Interface()))
+	case result.CanConvert(rv.Type()):
+		rv.Set(result.Convert(rv.Type()))
+	default:
+		return false
+	}
+	return true
+}
+
+// parseResult is reverse of formatResult
+func parseResult(rv reflect.Value, resultType reflect.Type) (reflect.Value, bool) {
+	if rv.Kind() != reflect.String {
+		if !rv.CanConvert(resultType) {
+			return reflect.Value{}, false
+		}
+		return rv.Convert(resultType), true
+	}
+
+	var value any
+	var err error
+	switch s := rv.String(); {
+	case resultType == typeTime:
+		value, err = time.Parse(time.RFC3339, s)
+	case resultType == typeUUID:
+		value, err = uuid.Parse(s)
+	case resultType == typeDecimal:
+		value, err = decimal.NewFromString(s)
+	case resultType == reflect.TypeFor[time.Duration]():
+		value, err = time.ParseDuration(s)
+	case resultType.Kind() == reflect.Float64:
+		value, err = strconv.ParseFloat(s, 64)
+	case resultType.Kind() == reflect.Int || resultType.Kind() == reflect.Int64:
+		value, err = strconv.ParseInt(s, 10, 64)
+	default:
+		return reflect.Value{}, false
+	}
+	if err != nil {
+		return reflect.Value{}, false
+	}
+	return reflect.ValueOf(value).Convert(resultType), true
+}
+
+func (g *generator) scalar(rv reflect.Value, tests []ecto.TestNode, required bool) {
+	typ := rv.Type()
+	if test, ok := findCode(tests, "ecto.OneOf"); ok {
+		variants := reflect.ValueOf(test.Params["variants"])
+		rv.Set(variants.Index(g.rnd.IntN(variants.Len())).Convert(typ))
+		return
+	}
+	if test, ok := findCode(tests, "ints.Eq"); ok {
+		rv.Set(reflect.ValueOf(test.Params["value"]).Convert(typ))
+		return
+	}
+
+	switch typ {
+	case typeTime:
+		rv.Set(reflect.ValueOf(g.time(tests)))
+		return
+	case typeUUID:
+		rv.Set(reflect.ValueOf(g.uuid(tests)))
+		return
+	case typeDecimal:
+		rv.Set(reflect.ValueOf(g.decimal(tests, required)))
+		return
+	case typeAddr:
+		rv.Set(reflect.ValueOf(g.addr(tests)))
+		return
+	}
+
+	switch {
+	case typ.Kind() == reflect.String:
+		rv.SetString(g.string(tests, required))
+	case rv.CanInt():
+		rv.SetInt(g.integer(typ, tests, required))
+	case rv.CanUint():
+		rv.SetUint(uint64(g.integer(typ, tests, required)))
+	case rv.CanFloat():
+		rv.SetFloat(g.float(tests, required))
+	case typ.Kind() == reflect.Bool:
+		rv.SetBool(required || g.rnd.IntN(2) == 0)
+	}
+}
+
+func (g *generator) string(tests []ecto.TestNode, required bool) string {
+	for _, test := range tests {
+		switch {
+		case samples[test.Code] != nil:
+			return samples[test.Code][g.rnd.IntN(len(samples[test.Code]))]
+		case test.Code == "strings.Phone" || test.Code == "strings.PhoneCountry":
+			code, _ := test.Params["code"].(string)
+			defaultCountry, _ := test.Params["defaultCountry"].(string)
+			region := cmp.Or(code, defaultCountry, "US")
+			return phonenumbers.Format(phonenumbers.GetExampleNumber(region), phonenumbers.E164)
+		case test.Code == "strings.Regex":
+			if re, err := NewRegex(fmt.Sprint(test.Params["regex"])); err == nil {
+				return re.Generate(g.rnd)
+			}
+		case test.Code == "strings.DateTime":
+			return g.time(nil).Format(fmt.Sprint(test.Params["layout"]))
+		}
+	}
+
+	low, high := lengthBounds(tests, "strings", required, maxExtra)
+	if test, ok := findCode(tests, "password.MinLength"); ok {
+		low = max(low, toInt(test.Params["length"]))
+		high = max(high, low)
+	}
+	chars := make([]byte, low+g.rnd.IntN(high-low+1))
+	for i := range chars {
+		chars[i] = alnum[g.rnd.IntN(len(alnum))]
+	}
+	// Passwords contain every class of characters
+	if slices.ContainsFunc(tests, func(test ecto.TestNode) bool { return strings.HasPrefix(test.Code, "password.") }) {
+		for i, class := range []string{"ABCDEFGHJKLMNPQRSTUVWXYZ", "abcdefghjkmnpqrstuvwxyz", "23456789", "!#%&*?@^"} {
+			if i < len(chars) {
+				chars[i] = class[g.rnd.IntN(len(class))]
+			}
+		}
+		g.rnd.Shuffle(len(chars), func(i, j int) { chars[i], chars[j] = chars[j], chars[i] })
+	}
+	return string(chars)
+}
+
+// lengthBounds of strings or slices restricted by tests of package. Unbounded length exceeds minimal by extra at most
+func lengthBounds(tests []ecto.TestNode, pkg string, required bool, extra int) (int, int) {
+	low, high := 0, -1
+	if required {
+		low = 1
+	}
+	for _, test := range tests {
+		switch strings.TrimPrefix(test.Code, pkg+".") {
+		case "Min", "MinBytes", "MinGraphemes":
+			low = max(low, toInt(test.Params["length"]))
+		case "Max", "MaxBytes", "MaxGraphemes":
+			high = minBound(high, toInt(test.Params["length"]))
+		case "Len":
+			low, high = toInt(test.Params["length"]), toInt(test.Params["length"])
+		}
+	}
+	if high < 0 {
+		high = low + extra
+	}
+	return low, max(low, high)
+}
+
+func (g *generator) integer(typ reflect.Type, tests []ecto.TestNode, required bool) int64 {
+	low, high := intRange(typ)
+	typeLow, typeHigh := low, high
+	step := int64(1)
+	for _, test := range tests {
+		_, name, _ := strings.Cut(test.Code, ".")
+		value := toInt64(test.Params["value"])
+		switch {
+		case test.Code == "network.Port":
+			low, high = max(low, 1), min(high, math.MaxUint16)
+		case name == "Min" || name == "MinDuration":
+			low = max(low, value)
+		case name == "Max" || name == "MaxDuration":
+			high = min(high, value)
+		case name == "MinExclusive" && value < math.MaxInt64:
+			low = max(low, value+1)
+		case name == "MaxExclusive" && value > math.MinInt64:
+			high = min(high, value-1)
+		case name == "Between":
+			low, high = max(low, toInt64(test.Params["min"])), min(high, toInt64(test.Params["max"]))
+		case name == "Positive":
+			low = max(low, 1)
+		case name == "NonNegative":
+			low = max(low, 0)
+		case name == "MultipleOf":
+			step = max(1, toInt64(test.Params["step"]))
+		}
+	}
+
+	// Unbounded range is narrowed around zero or the other bound
+	switch {
+	case low == typeLow && high == typeHigh:
+		low, high = max(low, -maxRange), min(high, maxRange)
+	case low == typeLow:
+		low = max(low, high-maxRange)
+	case high == typeHigh:
+		high = min(high, low+maxRange)
+	}
+	if low > high {
+		return low
+	}
+
+	value := low + g.rnd.Int64N(high-low+1)
+	if step > 1 {
+		value = value / step * step
+		if value < low {
+			value += step
+		}
+	}
+	if required && value == 0 {
+		value = lo.Ternary(high > 0, step, -step)
+	}
+	return value
+}
+
+func intRange(typ reflect.Type) (int64, int64) {
+	bits := typ.Bits()
+	if typ.Kind() >= reflect.Uint && typ.Kind() <= reflect.Uintptr {
+		if bits == 64 {
+			return 0, math.MaxInt64
+		}
+		return 0, 1<<bits - 1
+	}
+	return -1 << (bits - 1), 1<<(bits-1) - 1
+}
+
+func (g *generator) float(tests []ecto.TestNode, required bool) float64 {
+	low, high := math.Inf(-1), math.Inf(1)
+	precision := -1
+	for _, test := range tests {
+		_, name, _ := strings.Cut(test.Code, ".")
+		switch name {
+		case "Min":
+			low = max(low, toFloat(test.Params["value"]))
+		case "Max":
+			high = min(high, toFloat(test.Params["value"]))
+		case "MaxPrecision":
+			precision = toInt(test.Params["value"])
+		}
+	}
+	low, high = narrow(low, high)
+
+	value := low + g.rnd.Float64()*(high-low)
+	if precision >= 0 {
+		pow := math.Pow10(precision)
+		value = math.Round(value*pow) / pow
+	}
+	if required && value == 0 {
+		value = high
+	}
+	return value
+}
+
+func narrow(low, high float64) (float64, float64) {
+	switch {
+	case math.IsInf(low, -1) && math.IsInf(high, 1):
+		return -maxRange, maxRange
+	case math.IsInf(low, -1):
+		return high - maxRange, high
+	case math.IsInf(high, 1):
+		return low, low + maxRange
+	default:
+		return low, high
+	}
+}
+
+func (g *generator) decimal(tests []ecto.TestNode, required bool) decimal.Decimal {
+	low, high := math.Inf(-1), math.Inf(1)
+	scale := int32(2)
+	var step decimal.Decimal
+	for _, test := range tests {
+		value, _ := test.Params["value"].(decimal.Decimal)
+		switch test.Code {
+		case "decimals.Min":
+			low = max(low, value.InexactFloat64())
+		case "decimals.Max":
+			high = min(high, value.InexactFloat64())
+		case "decimals.Positive":
+			low = max(low, 1)
+		case "decimals.MaxScale":
+			scale = min(scale, int32(toInt(test.Params["value"])))
+		case "decimals.MultipleOf":
+			step, _ = test.Params["step"].(decimal.Decimal)
+		}
+	}
+	low, high = narrow(low, high)
+
+	value := decimal.NewFromFloat(low + g.rnd.Float64()*(high-low)).Round(scale)
+	if !step.IsZero() {
+		value = value.Div(step).Ceil().Mul(step)
+	}
+	if required && value.IsZero() {
+		value = decimal.NewFromFloat(high).Round(scale)
+	}
+	return value
+}
+
+func (g *generator) time(tests []ecto.TestNode) time.Time {
+	now := time.Now().UTC().Truncate(time.Second)
+	var low, high *time.Time
+	lower := func(t time.Time) {
+		if low == nil || t.After(*low) {
+			low = &t
+		}
+	}
+	upper := func(t time.Time) {
+		if high == nil || t.Before(*high) {
+			high = &t
+		}
+	}
+
+	var weekdays []time.Weekday
+	for _, test := range tests {
+		switch test.Code {
+		case "times.Before":
+			upper(test.Params["value"].(time.Time).Add(-time.Second))
+		case "times.After":
+			lower(test.Params["value"].(time.Time).Add(time.Second))
+		case "times.Between":
+			lower(test.Params["from"].(time.Time))
+			upper(test.Params["to"].(time.Time))
+		case "times.NotInFuture":
+			upper(now.Add(-time.Hour))
+		case "times.NotInPast":
+			lower(now.Add(time.Hour))
+		case "times.MinAge":
+			upper(now.AddDate(-toInt(test.Params["years"]), 0, -1))
+		case "times.MaxAge":
+			lower(now.AddDate(-toInt(test.Params["years"])-1, 0, 1))
+		case "times.Weekday":
+			weekdays, _ = test.Params["days"].([]time.Weekday)
+		}
+	}
+	switch {
+	case low == nil && high == nil:
+		low, high = lo.ToPtr(now.AddDate(-1, 0, 0)), lo.ToPtr(now.AddDate(1, 0, 0))
+	case low == nil:
+		low = lo.ToPtr(high.AddDate(-1, 0, 0))
+	case high == nil:
+		high = lo.ToPtr(low.AddDate(1, 0, 0))
+	}
+
+	value := *low
+	if span := high.Sub(*low); span > 0 {
+		value = low.Add(time.Duration(g.rnd.Int64N(int64(span)))).Truncate(time.Second)
+		if value.Before(*low) {
+			value = *low
+		}
+	}
+	for len(weekdays) > 0 && !slices.Contains(weekdays, value.Weekday()) {
+		value = value.AddDate(0, 0, 1)
+	}
+	return value
+}
+
+func (g *generator) uuid(tests []ecto.TestNode) uuid.UUID {
+	var id uuid.UUID
+	for i := range id {
+		id[i] = byte(g.rnd.UintN(256))
+	}
+
+	version := uuid.Version(4)
+	if test, ok := findCode(tests, "uuids.Version"); ok {
+		if versions, _ := test.Params["versions"].([]uuid.Version); len(versions) > 0 {
+			version = versions[g.rnd.IntN(len(versions))]
+		}
+	}
+	id[6] = id[6]&0x0f | byte(version)<<4
+	id[8] = id[8]&0x3f | 0x80 // RFC 4122 variant
+	return id
+}
+
+func (g *generator) addr(tests []ecto.TestNode) netip.Addr {
+	switch {
+	case hasCode(tests, "network.Loopback"):
+		return netip.MustParseAddr("127.0.0.1")
+	case hasCode(tests, "network.Private"):
+		return netip.AddrFrom4([4]byte{10, byte(g.rnd.UintN(256)), byte(g.rnd.UintN(256)), 1})
+	case hasCode(tests, "network.Is6"):
+		return netip.MustParseAddr("2606:4700:4700::1111")
+	default:
+		return netip.AddrFrom4([4]byte{8, 8, byte(g.rnd.UintN(2) * 4), 8})
+	}
+}
+
+func findCode(tests []ecto.TestNode, code string) (ecto.TestNode, bool) {
+	return lo.Find(tests, func(test ecto.TestNode) bool { return test.Code == code })
+}
+
+func hasCode(tests []ecto.TestNode, code string) bool {
+	_, ok := findCode(tests, code)
+	return ok
+}
+
+func toSlice(slice reflect.Value) []reflect.Value {
+	values := make([]reflect.Value, slice.Len())
+	for i := range values {
+		values[i] = slice.Index(i)
+	}
+	return values
+}
+
+// minBound is min treating negative bound as unlimited
+func minBound(bound, value int) int {
+	if bound < 0 {
+		return value
+	}
+	return min(bound, value)
+}
+
+func toInt(value any) int { return int(toInt64(value)) }
+
+func toInt64(value any) int64 {
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanInt():
+		return rv.Int()
+	case rv.CanUint():
+		return int64(min(rv.Uint(), math.MaxInt64))
+	case rv.CanFloat():
+		return int64(rv.Float())
+	default:
+		return 0
+	}
+}
+
+func toFloat(value any) float64 {
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanFloat():
+		return rv.Float()
+	case rv.CanInt():
+		return float64(rv.Int())
+	case rv.CanUint():
+		return float64(rv.Uint())
+	default:
+		return 0
+	}
+}
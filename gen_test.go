@@ -0,0 +1,150 @@
+package ecto_test
+
+import (
+	"math/rand/v2"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egsam98/ecto"
+	"github.com/egsam98/ecto/decimals"
+	"github.com/egsam98/ecto/floats"
+	"github.com/egsam98/ecto/gen"
+	integer "github.com/egsam98/ecto/ints"
+	ectosl "github.com/egsam98/ecto/slices"
+	ectos "github.com/egsam98/ecto/strings"
+	"github.com/egsam98/ecto/times"
+	"github.com/egsam98/ecto/uuids"
+)
+
+type Order struct {
+	ID       uuid.UUID       `json:"id"`
+	Code     string          `json:"code"`
+	Email    string          `json:"email"`
+	Status   string          `json:"status"`
+	Quantity int32           `json:"quantity"`
+	Discount float64         `json:"discount"`
+	Price    decimal.Decimal `json:"price"`
+	PaidAt   time.Time       `json:"paidAt"`
+	Tags     []string        `json:"tags"`
+	Note     *string         `json:"note"`
+	Items    []Node          `json:"items"`
+}
+
+func orderSchema() ecto.StructSchema[Order] {
+	var node ecto.StructSchema[Node]
+	node = ecto.Struct[Node](ecto.M{
+		"Name":     ecto.String().Required().Test(ectos.Max(5)),
+		"Children": ecto.Slice[[]Node](ecto.Lazy[Node](func() ecto.Schema { return node })),
+	})
+
+	return ecto.Struct[Order](ecto.M{
+		"ID":       ecto.Atomic[uuid.UUID]().Required().Test(uuids.Version(7)),
+		"Code":     ecto.String().Required().Test(ectos.Regex(regexp.MustCompile(`^[A-Z]{3}-\d{4}$`))),
+		"Email":    ecto.String().Test(ectos.Email()),
+		"Status":   ecto.String().Test(ecto.OneOf("new", "paid")),
+		"Quantity": ecto.Atomic[int32]().Test(integer.Between[int32](1, 100), integer.MultipleOf[int32](5)),
+		"Discount": ecto.Float().Test(floats.Min(0), floats.Max(0.5), floats.MaxPrecision(2)),
+		"Price":    ecto.Atomic[decimal.Decimal]().Required().Test(decimals.Positive(), decimals.MaxScale(2)),
+		"PaidAt":   ecto.Atomic[time.Time]().Test(times.NotInFuture(), times.Weekday(time.Monday, time.Tuesday)),
+		"Tags": ecto.Slice[[]string](ecto.String().Test(ectos.Min(2), ectos.Max(8))).
+			Test(ectosl.Min[[]string](1), ectosl.Max[[]string](3), ectosl.Unique[[]string]()),
+		"Note":  ecto.Ptr[string](ecto.String().Test(ectos.Trimmed())),
+		"Items": ecto.Slice[[]Node](node),
+	})
+}
+
+func TestGenerator(t *testing.T) {
+	schema := orderSchema()
+	g := gen.New(schema)
+	violated := make(map[string]bool)
+	for seed := range uint64(200) {
+		rnd := rand.New(rand.NewPCG(seed, seed))
+
+		order, err := g.Valid(rnd)
+		require.NoError(t, err)
+		require.NoError(t, schema.Process(&order))
+
+		order, violation, err := g.Invalid(rnd)
+		require.NoError(t, err)
+		require.EqualError(t, schema.Process(&order), violation.Err().Error())
+		violated[violation.Test.Code] = true
+	}
+
+	for _, code := range []string{
+		gen.Required, "uuids.Version", "strings.Regex", "strings.Email", "ecto.OneOf", "ints.Between",
+		"ints.MultipleOf", "floats.Max", "decimals.MaxScale", "times.NotInFuture", "times.Weekday",
+		"slices.Max", "slices.UniqueBy", "strings.Trimmed", "strings.Max",
+	} {
+		assert.True(t, violated[code], code)
+	}
+
+	t.Run("converted", func(t *testing.T) {
+		type Payment struct {
+			ID     string `json:"id"`
+			Count  int32  `json:"count"`
+			Amount string `json:"amount"`
+			PaidAt string `json:"paidAt"`
+		}
+		schema := ecto.Struct[Payment](ecto.M{
+			"ID":     ecto.UUIDFrom[string]().Test(uuids.Version(7)),
+			"Count":  ecto.IntFrom[int32]().Test(integer.Max(10)),
+			"Amount": ecto.DecimalFrom[string]().Test(decimals.MaxScale(2)),
+			"PaidAt": ecto.TimeFrom[string]().Test(times.NotInFuture()),
+		})
+		g := gen.New(schema)
+		violated := make(map[string]bool)
+		for seed := range uint64(100) {
+			payment, violation, err := g.Invalid(rand.New(rand.NewPCG(seed, seed)))
+			require.NoError(t, err)
+			require.EqualError(t, schema.Process(&payment), violation.Err().Error())
+			violated[violation.Test.Code] = true
+		}
+		for _, code := range []string{"uuids.Version", "ints.Max", "decimals.MaxScale", "times.NotInFuture"} {
+			assert.True(t, violated[code], code)
+		}
+	})
+
+	t.Run("no rules", func(t *testing.T) {
+		schema := ecto.Struct[Node](ecto.M{"Name": ecto.String()})
+		_, _, err := gen.New(schema, gen.MaxAttempts(3)).Invalid(rand.New(rand.NewPCG(0, 0)))
+		assert.ErrorIs(t, err, gen.ErrNoRules)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		schema := ecto.Struct[Node](ecto.M{"Name": ecto.String().Test(ecto.Test[string]{
+			Error: "never",
+			Func:  func(*string) bool { return false },
+		})})
+		_, err := gen.New(schema, gen.MaxAttempts(3)).Valid(rand.New(rand.NewPCG(0, 0)))
+		assert.ErrorContains(t, err, "never")
+	})
+}
+
+func TestRegex(t *testing.T) {
+	for _, expr := range []string{`^[A-Z]{3}-\d{4}$`, `(?i)^foo(bar|baz)+\.go$`, `^\w+@[a-z]+\.(com|org)$`} {
+		re, err := gen.NewRegex(expr)
+		require.NoError(t, err)
+		rnd := rand.New(rand.NewPCG(1, 2))
+		for range 50 {
+			assert.Regexp(t, expr, re.Generate(rnd))
+		}
+	}
+}
+
+func FuzzGenerator(f *testing.F) {
+	schema := orderSchema()
+	gen.New(schema).Fuzz(f, func(t *testing.T, order Order, violation *gen.Violation) {
+		err := schema.Process(&order)
+		if violation == nil {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, violation.Err().Error(), violation.String())
+		}
+	})
+}
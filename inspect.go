@@ -0,0 +1,227 @@
+package ecto
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// NodeKind is a kind of schema described by Node
+type NodeKind string
+
+const (
+	KindAtomic NodeKind = "atomic"
+	KindStruct NodeKind = "struct"
+	KindSlice  NodeKind = "slice"
+	KindPtr    NodeKind = "ptr"
+	KindLazy   NodeKind = "lazy"
+)
+
+// Node describes schema for introspection (debugging, documentation, exporters). It's serializable to JSON
+type Node struct {
+	Kind NodeKind `json:"kind"`
+	// Type is Go type processed by schema (see Schema.ForType)
+	Type string `json:"type"`
+	// Result is Go type tests are applied to if atomic schema converts data (see AtomicFrom)
+	Result     string       `json:"result,omitempty"`
+	Required   bool         `json:"required,omitempty"`
+	OmitZero   bool         `json:"omitZero,omitempty"`
+	Default    *DefaultNode `json:"default,omitempty"`
+	Transforms int          `json:"transforms,omitempty"`
+	Tests      []TestNode   `json:"tests,omitempty"`
+	// Fields of struct in order of declaration
+	Fields []FieldNode `json:"fields,omitempty"`
+	// Elem is a schema of slice elements, pointee or resolved lazy schema
+	Elem     *Node `json:"elem,omitempty"`
+	MaxDepth uint  `json:"maxDepth,omitempty"`
+	// Recursive reports schema of type being described up the tree, its Elem and Fields are omitted
+	Recursive bool `json:"recursive,omitempty"`
+}
+
+// FieldNode describes schema of struct field
+type FieldNode struct {
+	Name string `json:"name"`
+	// Tag is a key of field in MapError (see FieldMeta)
+	Tag  string `json:"tag"`
+	Node Node   `json:"node"`
+}
+
+// TestNode describes Test, Code and Params are empty for custom tests
+type TestNode struct {
+	Code   string         `json:"code,omitempty"`
+	Params map[string]any `json:"params,omitempty"`
+	Error  Error          `json:"error"`
+	// contextual tests need Parent
+	contextual  bool
+	checkParent func(typ reflect.Type) error
+}
+
+// DefaultNode describes default value, Value is omitted for generated ones (see AtomicSchema.DefaultFunc)
+type DefaultNode struct {
+	Value     any  `json:"value,omitempty"`
+	Generated bool `json:"generated,omitempty"`
+}
+
+// Inspect describes schema tree. Lazy schemas are resolved unless they're recursive
+func Inspect(schema Schema) Node {
+	return schema.describe(&describer{visiting: make(map[any]bool)})
+}
+
+// Walk calls fn for the node and its descendants depth-first, descendants are skipped if fn returns false.
+// Path consists of field tags, "[]" for slice elements, "*" for pointees and "~" for resolved lazy schemas
+func (n *Node) Walk(fn func(path []string, node *Node) bool) { n.walk(nil, fn) }
+
+func (n *Node) walk(path []string, fn func(path []string, node *Node) bool) {
+	if !fn(path, n) {
+		return
+	}
+	for i := range n.Fields {
+		n.Fields[i].Node.walk(append(slices.Clip(path), n.Fields[i].Tag), fn)
+	}
+	if n.Elem != nil {
+		n.Elem.walk(append(slices.Clip(path), elemPath[n.Kind]), fn)
+	}
+}
+
+var elemPath = map[NodeKind]string{KindSlice: "[]", KindPtr: "*", KindLazy: "~"}
+
+// Describe pretty-prints schema tree, ex.
+//
+//	Struct[main.User] {
+//	    Email (email): Atomic[string] required tests=[strings.Email]
+//	    Tags (tags): Slice[[]string] tests=[slices.Max(length=10)] of Atomic[string] tests=[strings.Min(length=2)]
+//	}
+func Describe(schema Schema) string {
+	node := Inspect(schema)
+	var b strings.Builder
+	node.describe(&b, 0)
+	return b.String()
+}
+
+func (n *Node) describe(b *strings.Builder, depth int) {
+	b.WriteString(strings.ToUpper(string(n.Kind[:1])) + string(n.Kind[1:]) + "[" + n.Type)
+	if n.Result != "" {
+		b.WriteString(" -> " + n.Result)
+	}
+	b.WriteString("]")
+
+	var attrs []string
+	if n.Required {
+		attrs = append(attrs, "required")
+	}
+	if n.OmitZero {
+		attrs = append(attrs, "omitzero")
+	}
+	if n.Default != nil {
+		if n.Default.Generated {
+			attrs = append(attrs, "default=<generated>")
+		} else {
+			attrs = append(attrs, fmt.Sprintf("default=%v", n.Default.Value))
+		}
+	}
+	if n.Transforms > 0 {
+		attrs = append(attrs, fmt.Sprintf("transforms=%d", n.Transforms))
+	}
+	if n.MaxDepth > 0 {
+		attrs = append(attrs, fmt.Sprintf("maxdepth=%d", n.MaxDepth))
+	}
+	if len(n.Tests) > 0 {
+		attrs = append(attrs, "tests=["+strings.Join(lo.Map(n.Tests, func(test TestNode, _ int) string {
+			return test.String()
+		}), ", ")+"]")
+	}
+	if n.Recursive {
+		attrs = append(attrs, "<recursive>")
+	}
+	for _, attr := range attrs {
+		b.WriteString(" " + attr)
+	}
+
+	switch {
+	case n.Elem != nil:
+		b.WriteString(" of ")
+		n.Elem.describe(b, depth)
+	case n.Kind == KindStruct && !n.Recursive:
+		b.WriteString(" {\n")
+		indent := strings.Repeat("    ", depth+1)
+		for _, field := range n.Fields {
+			b.WriteString(indent + field.Name)
+			if field.Tag != field.Name {
+				b.WriteString(" (" + field.Tag + ")")
+			}
+			b.WriteString(": ")
+			field.Node.describe(b, depth+1)
+			b.WriteString("\n")
+		}
+		b.WriteString(strings.Repeat("    ", depth) + "}")
+	}
+}
+
+// String formats test as code with parameters sorted by name, ex. "strings.Min(length=3)".
+// Custom tests are formatted as quoted error
+func (t TestNode) String() string {
+	if t.Code == "" {
+		return fmt.Sprintf("%q", t.Error)
+	}
+	if len(t.Params) == 0 {
+		return t.Code
+	}
+
+	keys := lo.Keys(t.Params)
+	slices.Sort(keys)
+	return t.Code + "(" + strings.Join(lo.Map(keys, func(key string, _ int) string {
+		return fmt.Sprintf("%s=%v", key, t.Params[key])
+	}), ", ") + ")"
+}
+
+// describer guards recursion of lazy and tag-driven schemas
+type describer struct {
+	visiting map[any]bool
+	// unresolved lazy schemas, ex. during construction of recursive ones
+	unresolved bool
+}
+
+// enter marks key as being described, returns false if it's already described up the tree
+func (d *describer) enter(key any) bool {
+	if d.visiting[key] {
+		return false
+	}
+	d.visiting[key] = true
+	return true
+}
+
+func (d *describer) leave(key any) { delete(d.visiting, key) }
+
+func newTestNode[T any](test Test[T]) TestNode {
+	return TestNode{
+		Code:        test.Code,
+		Params:      test.Params,
+		Error:       test.Error,
+		contextual:  test.FuncContext != nil,
+		checkParent: test.CheckParent,
+	}
+}
+
+func newDefaultNode[T any](set bool, value *T) *DefaultNode {
+	switch {
+	case !set:
+		return nil
+	case value == nil:
+		return &DefaultNode{Generated: true}
+	default:
+		return &DefaultNode{Value: *value}
+	}
+}
+
+// describeFields lists schemas of struct fields in order of declaration
+func describeFields(d *describer, fields M, meta map[string]FieldMeta) []FieldNode {
+	nodes := make([]FieldNode, 0, len(fields))
+	for key, schema := range fields {
+		nodes = append(nodes, FieldNode{Name: key, Tag: meta[key].Tag, Node: schema.describe(d)})
+	}
+	slices.SortFunc(nodes, func(a, b FieldNode) int { return meta[a.Name].Index - meta[b.Name].Index })
+	return nodes
+}
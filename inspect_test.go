@@ -0,0 +1,274 @@
+package ecto_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egsam98/ecto"
+	"github.com/egsam98/ecto/ectodiff"
+	"github.com/egsam98/ecto/ectots"
+	integer "github.com/egsam98/ecto/ints"
+	ectosl "github.com/egsam98/ecto/slices"
+	ectos "github.com/egsam98/ecto/strings"
+)
+
+type Profile struct {
+	Email string   `json:"email"`
+	Tags  []string `json:"tags"`
+	Age   *int     `json:"age"`
+	Role  string
+}
+
+func TestInspect(t *testing.T) {
+	schema := ecto.Struct[Profile](ecto.M{
+		"Email": ecto.String().Required().Test(ectos.Email()),
+		"Tags":  ecto.Slice[[]string](ecto.String().Test(ectos.Min(2))).Test(ectosl.Max[[]string](10)),
+		"Age":   ecto.Ptr[int](ecto.Int().Default(18)),
+		"Role":  ecto.String().Test(ecto.Test[string]{Error: "custom", Func: func(*string) bool { return true }}),
+	})
+
+	node := ecto.Inspect(schema)
+	assert.Equal(t, ecto.KindStruct, node.Kind)
+	assert.Equal(t, "ecto_test.Profile", node.Type)
+	require.Len(t, node.Fields, 4)
+	assert.Equal(t, []string{"Email", "Tags", "Age", "Role"}, lo.Map(node.Fields, func(f ecto.FieldNode, _ int) string {
+		return f.Name
+	}))
+	assert.Equal(t, ecto.TestNode{Code: "strings.Email", Error: "invalid email address"}, node.Fields[0].Node.Tests[0])
+	assert.Equal(t, &ecto.DefaultNode{Value: 18}, node.Fields[2].Node.Elem.Default)
+
+	var paths []string
+	node.Walk(func(path []string, node *ecto.Node) bool {
+		paths = append(paths, strings.Join(path, ".")+"="+string(node.Kind))
+		return node.Kind != ecto.KindSlice
+	})
+	assert.Equal(t, []string{"=struct", "email=atomic", "tags=slice", "age=ptr", "age.*=atomic", "Role=atomic"}, paths)
+
+	assert.Equal(t, `Struct[ecto_test.Profile] {
+    Email (email): Atomic[string] required tests=[strings.Email]
+    Tags (tags): Slice[[]string] tests=[slices.Max(length=10)] of Atomic[string] tests=[strings.Min(length=2)]
+    Age (age): Ptr[*int] of Atomic[int] default=18
+    Role: Atomic[string] tests=["custom"]
+}`, ecto.Describe(schema))
+
+	t.Run("recursive", func(t *testing.T) {
+		var schema ecto.StructSchema[Node]
+		schema = ecto.Struct[Node](ecto.M{
+			"Name":     ecto.String(),
+			"Children": ecto.Slice[[]Node](ecto.Lazy[Node](func() ecto.Schema { return schema }).MaxDepth(2)),
+		})
+
+		assert.Equal(t, `Struct[ecto_test.Node] {
+    Name: Atomic[string]
+    Children: Slice[[]ecto_test.Node] of Lazy[ecto_test.Node] maxdepth=2 of Struct[ecto_test.Node] {
+        Name: Atomic[string]
+        Children: Slice[[]ecto_test.Node] of Lazy[ecto_test.Node] maxdepth=2 <recursive>
+    }
+}`, ecto.Describe(schema))
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		var tests []string
+		node := ecto.Inspect(ecto.FromTags[Tagged]())
+		node.Walk(func(path []string, node *ecto.Node) bool {
+			for _, test := range node.Tests {
+				tests = append(tests, strings.Join(path, ".")+" "+test.String())
+			}
+			return true
+		})
+		assert.Contains(t, tests, "name strings.Min(length=3)")
+		assert.Contains(t, tests, "tags slices.Min(length=1)")
+		assert.Contains(t, tests, "children.[].*.name strings.Min(length=3)")
+	})
+}
+
+func TestDiff(t *testing.T) {
+	old := ecto.Struct[Profile](ecto.M{
+		"Email": ecto.String().Test(ectos.Max(100)),
+		"Tags":  ecto.Slice[[]string](ecto.String().Test(ectos.Min(2))),
+		"Age":   ecto.Ptr[int](ecto.Int()),
+		"Role":  ecto.String().Test(ecto.OneOf("admin", "user")),
+	})
+	// Old snapshot is decoded as if it's read from file
+	data, err := json.Marshal(ecto.Inspect(old))
+	require.NoError(t, err)
+	var oldNode ecto.Node
+	require.NoError(t, json.Unmarshal(data, &oldNode))
+
+	changes := ectodiff.Compare(oldNode, ecto.Inspect(ecto.Struct[Profile](ecto.M{
+		"Email": ecto.String().Required().Test(ectos.Max(50)),
+		"Tags":  ecto.Slice[[]string](ecto.String().Test(ectos.Min(1))),
+		"Age":   ecto.Ptr[int](ecto.Int().Test(ecto.OneOf(1, 2))),
+		"Role":  ecto.String().Test(ecto.OneOf("admin", "user", "guest")),
+	})))
+	assert.Equal(t, []string{
+		"email: became required (tightened)",
+		"email: test strings.Max(length=100) changed to strings.Max(length=50) (tightened)",
+		"tags.[]: test strings.Min(length=2) changed to strings.Min(length=1) (loosened)",
+		"age.*: test ecto.OneOf(variants=[1 2]) added (tightened)",
+		"Role: test ecto.OneOf(variants=[admin user]) changed to ecto.OneOf(variants=[admin user guest]) (loosened)",
+	}, lo.Map(changes, func(change ectodiff.Change, _ int) string { return change.String() }))
+	assert.Len(t, ectodiff.Breaking(changes, ectodiff.Request), 3)
+	assert.Len(t, ectodiff.Breaking(changes, ectodiff.Response), 2)
+
+	t.Run("fields", func(t *testing.T) {
+		changes := ectodiff.Compare(oldNode, ecto.Inspect(ecto.Struct[Profile](ecto.M{
+			"Email": ecto.FloatFrom[string](),
+			"Age":   ecto.Ptr[int](ecto.Int()),
+			"Role":  ecto.String().Required(),
+		})))
+		assert.Equal(t, []string{
+			"email: type changed from atomic string to atomic string -> float64 (incompatible)",
+			"tags: field removed (loosened)",
+			"Role: became required (tightened)",
+			"Role: test ecto.OneOf(variants=[admin user]) removed (loosened)",
+		}, lo.Map(changes, func(change ectodiff.Change, _ int) string { return change.String() }))
+	})
+
+	t.Run("omitzero", func(t *testing.T) {
+		schema := func(email, role ecto.AtomicSchema[string, string]) ecto.Node {
+			return ecto.Inspect(ecto.Struct[Profile](ecto.M{"Email": email, "Role": role}))
+		}
+		old := schema(ecto.String().Test(ectos.Email()), ecto.String().Default("user"))
+		new := schema(ecto.String().Test(ectos.Email()).OmitZero(), ecto.String().Default("user").OmitZero())
+		describe := func(changes []ectodiff.Change) []string {
+			return lo.Map(changes, func(change ectodiff.Change, _ int) string { return change.String() })
+		}
+
+		changes := ectodiff.Compare(old, new)
+		assert.Equal(t, []string{"email: omitzero enabled (loosened)", "Role: omitzero enabled (loosened)"}, describe(changes))
+		assert.Empty(t, ectodiff.Breaking(changes, ectodiff.Request))
+		assert.Len(t, ectodiff.Breaking(changes, ectodiff.Response), 2)
+
+		changes = ectodiff.Compare(new, old)
+		assert.Equal(t, []string{"email: omitzero disabled (tightened)", "Role: omitzero disabled (tightened)"}, describe(changes))
+		assert.Len(t, ectodiff.Breaking(changes, ectodiff.Request), 2)
+		assert.Empty(t, ectodiff.Breaking(changes, ectodiff.Response))
+
+		changes = ectodiff.Compare(
+			schema(ecto.String().Required(), ecto.String()),
+			schema(ecto.String().Required().OmitZero(), ecto.String().OmitZero()),
+		)
+		assert.Equal(t, []string{"email: omitzero enabled (compatible)", "Role: omitzero enabled (compatible)"}, describe(changes))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		schema := func(email, role ecto.AtomicSchema[string, string]) ecto.Node {
+			return ecto.Inspect(ecto.Struct[Profile](ecto.M{"Email": email, "Role": role}))
+		}
+		describe := func(changes []ectodiff.Change) []string {
+			return lo.Map(changes, func(change ectodiff.Change, _ int) string { return change.String() })
+		}
+		tested := ecto.String().Test(ectos.Email())
+		old := schema(tested.Default("admin@example.com"), ecto.String().Default("user"))
+		new := schema(tested, ecto.String())
+
+		changes := ectodiff.Compare(old, new)
+		assert.Equal(t, []string{"email: default removed (tightened)", "Role: default removed (compatible)"}, describe(changes))
+		assert.Len(t, ectodiff.Breaking(changes, ectodiff.Request), 1)
+
+		changes = ectodiff.Compare(new, old)
+		assert.Equal(t, []string{"email: default added (loosened)", "Role: default added (compatible)"}, describe(changes))
+		assert.Empty(t, ectodiff.Breaking(changes, ectodiff.Request))
+
+		changes = ectodiff.Compare(schema(tested.OmitZero().Default("admin@example.com"), ecto.String()), schema(tested.OmitZero(), ecto.String()))
+		assert.Equal(t, []string{"email: default removed (compatible)"}, describe(changes))
+	})
+}
+
+func TestExportTS(t *testing.T) {
+	var node ecto.StructSchema[Node]
+	node = ecto.Struct[Node](ecto.M{
+		"Name":     ecto.String().Required(),
+		"Children": ecto.Slice[[]Node](ecto.Lazy[Node](func() ecto.Schema { return node })),
+	})
+	profile := ecto.Struct[Profile](ecto.M{
+		"Email": ecto.String().Required().Test(ectos.Email(), ectos.Phone("US")),
+		"Tags":  ecto.Slice[[]string](ecto.String().Test(ectos.Min(2))).Test(ectosl.Max[[]string](10)),
+		"Age":   ecto.Ptr[int](ecto.Int().Test(integer.Min(18))),
+		"Role":  ecto.String().Test(ecto.OneOf("admin", "user")).Default("user"),
+	})
+
+	var b strings.Builder
+	require.NoError(t, ectots.Export(&b, profile, node))
+	assert.Equal(t, `// Code generated by ectots. DO NOT EDIT.
+
+import { z } from "zod";
+
+export interface Profile {
+  email: string;
+  tags?: string[] | null;
+  age?: number | null;
+  Role: "admin" | "user";
+}
+
+// Not exported test of email: strings.Phone(defaultCountry=US)
+export const ProfileSchema: z.ZodType<Profile, z.ZodTypeDef, unknown> = z.object({
+  email: z.string().min(1, "required").email("invalid email address"),
+  tags: z.array(z.string().min(2, "must be at least 2 characters long")).max(10, "must contain at most 10 items").nullable().optional(),
+  age: z.number().int().min(18, "must be 18 minimum").nullable().optional(),
+  Role: z.preprocess((v) => (v === "" ? undefined : v), z.enum(["admin", "user"], { message: "must be one of [admin user]" }).default("user")),
+});
+
+export interface Node {
+  Name: string;
+  Children?: Node[] | null;
+}
+
+export const NodeSchema: z.ZodType<Node, z.ZodTypeDef, unknown> = z.object({
+  Name: z.string().min(1, "required"),
+  Children: z.array(z.lazy(() => NodeSchema)).nullable().optional(),
+});
+`, b.String())
+
+	assert.Error(t, ectots.Export(&b, ecto.String()))
+
+	t.Run("zero values", func(t *testing.T) {
+		type Limits struct {
+			Size    int
+			Timeout *int
+			Token   string
+			Name    string
+			Email   string
+			Nick    string
+			Note    string
+		}
+		schema := ecto.Struct[Limits](ecto.M{
+			"Size":    ecto.Int().Default(10),
+			"Timeout": ecto.Ptr[int](ecto.Int()).Default(30),
+			"Token":   ecto.String().DefaultFunc(func() string { return "token" }),
+			"Name":    ecto.String().Required().Default("limits"),
+			"Email":   ecto.String().Test(ectos.Email()),
+			"Nick":    ecto.String().Test(ectos.Min(3)).OmitZero(),
+			"Note":    ecto.String(),
+		})
+
+		var b strings.Builder
+		require.NoError(t, ectots.Export(&b, schema))
+		assert.Contains(t, b.String(), `
+export interface Limits {
+  Size: number;
+  Timeout: number | null;
+  Token?: string;
+  Name: string;
+  Email: string;
+  Nick?: string;
+  Note?: string;
+}
+`)
+		assert.Contains(t, b.String(), `
+  Size: z.preprocess((v) => (v === 0 ? undefined : v), z.number().int().default(10)),
+  Timeout: z.preprocess((v) => (v === null ? undefined : v), z.number().int().nullable().default(30)),
+  Token: z.preprocess((v) => (v === "" ? undefined : v), z.string().optional()).optional(),
+  Name: z.string().min(1, "required"),
+  Email: z.string().email("invalid email address"),
+  Nick: z.preprocess((v) => (v === "" ? undefined : v), z.string().min(3, "must be at least 3 characters long").optional()).optional(),
+  Note: z.string().optional(),
+`)
+	})
+}
@@ -0,0 +1,98 @@
+// Package text parses values of struct fields from text, ex. of environment variables and CSV cells
+package text
+
+import (
+	"encoding"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/egsam98/ecto"
+)
+
+var (
+	typeDuration        = reflect.TypeFor[time.Duration]()
+	typeTextUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// Parsable reports whether values of type are parsed from text
+func Parsable(typ reflect.Type) bool {
+	if typ == typeDuration || reflect.PointerTo(typ).Implements(typeTextUnmarshaler) {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// Parse sets value of Parsable type or pointer to it. Integers are decimal, so zero-padded ones (ex. "010")
+// keep their values
+func Parse(rv reflect.Value, value string) *ecto.Error {
+	if rv.Kind() == reflect.Pointer {
+		rv.Set(reflect.New(rv.Type().Elem()))
+		rv = rv.Elem()
+	}
+
+	var err error
+	switch typ := rv.Type(); {
+	case reflect.PointerTo(typ).Implements(typeTextUnmarshaler):
+		err = rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	case typ == typeDuration:
+		var d time.Duration
+		if d, err = time.ParseDuration(value); err == nil {
+			rv.SetInt(int64(d))
+		}
+	case rv.Kind() == reflect.String:
+		rv.SetString(value)
+	case rv.Kind() == reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(value); err == nil {
+			rv.SetBool(b)
+		}
+	case rv.CanInt():
+		var i int64
+		if i, err = strconv.ParseInt(value, 10, typ.Bits()); err == nil {
+			rv.SetInt(i)
+		}
+	case rv.CanUint():
+		var u uint64
+		if u, err = strconv.ParseUint(value, 10, typ.Bits()); err == nil {
+			rv.SetUint(u)
+		}
+	case rv.CanFloat():
+		var f float64
+		if f, err = strconv.ParseFloat(value, typ.Bits()); err == nil {
+			rv.SetFloat(f)
+		}
+	}
+	if err != nil {
+		msg := ecto.Errorf("invalid %s %q", rv.Type(), value)
+		return &msg
+	}
+	return nil
+}
+
+// Flatten lists messages of nested error prefixed by their keys, ex. "0: required" for list item
+func Flatten(err error) []ecto.Error {
+	switch err := err.(type) {
+	case ecto.ListError:
+		return err
+	case ecto.MapError:
+		var res []ecto.Error
+		for _, key := range slices.Sorted(maps.Keys(err)) {
+			for _, msg := range Flatten(err[key]) {
+				res = append(res, ecto.Errorf("%s: %s", key, msg))
+			}
+		}
+		return res
+	default:
+		return nil
+	}
+}
@@ -1,29 +1,112 @@
 package integer
 
 import (
+	"github.com/egsam98/errors"
+	"golang.org/x/exp/constraints"
+
 	"github.com/egsam98/ecto"
 )
 
 // Eq forces a value to be equal to another
-func Eq(value int) ecto.Test[int] {
-	return ecto.Test[int]{
-		Error: ecto.Errorf("must be equal to %d", value),
-		Func:  func(v *int) bool { return *v == value },
+func Eq[T constraints.Integer](value T) ecto.Test[T] {
+	return ecto.Test[T]{
+		Code:   "ints.Eq",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Errorf("must be equal to %d", value),
+		Func:   func(v *T) bool { return *v == value },
+	}
+}
+
+// NotEq forbids a value to be equal to another
+func NotEq[T constraints.Integer](value T) ecto.Test[T] {
+	return ecto.Test[T]{
+		Code:   "ints.NotEq",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Errorf("must not be equal to %d", value),
+		Func:   func(v *T) bool { return *v != value },
 	}
 }
 
 // Min restricts value with lower inclusive bound
-func Min(value int) ecto.Test[int] {
-	return ecto.Test[int]{
-		Error: ecto.Errorf("must be %d minimum", value),
-		Func:  func(v *int) bool { return *v >= value },
+func Min[T constraints.Integer](value T) ecto.Test[T] {
+	return ecto.Test[T]{
+		Code:   "ints.Min",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Errorf("must be %d minimum", value),
+		Func:   func(v *T) bool { return *v >= value },
 	}
 }
 
 // Max restricts value with upper inclusive bound
-func Max(value int) ecto.Test[int] {
-	return ecto.Test[int]{
-		Error: ecto.Errorf("must be %d maximum", value),
-		Func:  func(v *int) bool { return *v <= value },
+func Max[T constraints.Integer](value T) ecto.Test[T] {
+	return ecto.Test[T]{
+		Code:   "ints.Max",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Errorf("must be %d maximum", value),
+		Func:   func(v *T) bool { return *v <= value },
+	}
+}
+
+// MinExclusive restricts value with lower exclusive bound
+func MinExclusive[T constraints.Integer](value T) ecto.Test[T] {
+	return ecto.Test[T]{
+		Code:   "ints.MinExclusive",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Errorf("must be greater than %d", value),
+		Func:   func(v *T) bool { return *v > value },
+	}
+}
+
+// MaxExclusive restricts value with upper exclusive bound
+func MaxExclusive[T constraints.Integer](value T) ecto.Test[T] {
+	return ecto.Test[T]{
+		Code:   "ints.MaxExclusive",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Errorf("must be less than %d", value),
+		Func:   func(v *T) bool { return *v < value },
+	}
+}
+
+// Between restricts value with both inclusive bounds
+func Between[T constraints.Integer](min, max T) ecto.Test[T] {
+	if min > max {
+		panic(errors.Errorf("invalid range [%d, %d]", min, max))
+	}
+	return ecto.Test[T]{
+		Code:   "ints.Between",
+		Params: map[string]any{"min": min, "max": max},
+		Error:  ecto.Errorf("must be between %d and %d", min, max),
+		Func:   func(v *T) bool { return *v >= min && *v <= max },
+	}
+}
+
+// Positive forces value to be greater than zero
+func Positive[T constraints.Integer]() ecto.Test[T] {
+	return ecto.Test[T]{
+		Code:  "ints.Positive",
+		Error: "must be positive",
+		Func:  func(v *T) bool { return *v > 0 },
+	}
+}
+
+// NonNegative forces value to be zero or greater
+func NonNegative[T constraints.Integer]() ecto.Test[T] {
+	return ecto.Test[T]{
+		Code:  "ints.NonNegative",
+		Error: "must not be negative",
+		Func:  func(v *T) bool { return *v >= 0 },
+	}
+}
+
+// MultipleOf forces value to be divisible by step without remainder
+func MultipleOf[T constraints.Integer](step T) ecto.Test[T] {
+	if step == 0 {
+		panic(errors.New("step must not be zero"))
+	}
+	return ecto.Test[T]{
+		Code:   "ints.MultipleOf",
+		Params: map[string]any{"step": step},
+		Error:  ecto.Errorf("must be a multiple of %d", step),
+		Func:   func(v *T) bool { return *v%step == 0 },
 	}
 }
@@ -0,0 +1,95 @@
+package ecto
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/egsam98/errors"
+)
+
+var _ Schema = (*LazySchema[any])(nil)
+var _ ILazySchema = (*LazySchema[any])(nil)
+var _ TypedSchema[any] = (*LazySchema[any])(nil)
+
+// LazySchema defers construction of inner Schema until first use, so it's able to reference itself
+// (recursive types like trees). Features:
+// - Validate resolved schema against T once
+// - Restrict nesting depth at runtime
+type LazySchema[T any] struct {
+	resolve  func() Schema
+	maxDepth uint
+}
+
+// Lazy wraps function returning inner schema. Example:
+//
+//	var node ecto.StructSchema[Node]
+//	node = ecto.Struct[Node](ecto.M{
+//		"Children": ecto.Slice[[]Node](ecto.Lazy[Node](func() ecto.Schema { return node })),
+//	})
+func Lazy[T any](fn func() Schema) LazySchema[T] {
+	var self LazySchema[T]
+	self.resolve = sync.OnceValue(func() Schema {
+		inner := fn()
+		if err := validateSchema(reflect.TypeFor[T](), inner); err != nil {
+			panic(errors.Wrapf(err, "%T", self))
+		}
+		return inner
+	})
+	return self
+}
+
+// MaxDepth restricts number of nested lazy schemas entered during processing. Zero means no restriction
+func (s LazySchema[T]) MaxDepth(value uint) LazySchema[T] {
+	s.maxDepth = value
+	return s
+}
+
+// Process may return errors of inner schema or ListError if maximum depth is exceeded
+func (s LazySchema[T]) Process(data *T) error { return s.process(context.Background(), data) }
+
+// ProcessContext is Process with context passed to inner schema
+func (s LazySchema[T]) ProcessContext(ctx context.Context, data *T) error {
+	return s.process(ctx, data)
+}
+
+func (s LazySchema[T]) process(ctx context.Context, ptr any) error {
+	depth, _ := ctx.Value(lazyDepthKey{}).(uint)
+	depth++
+	if s.maxDepth > 0 && depth > s.maxDepth {
+		return ListError{Errorf("exceeds maximum nesting depth of %d", s.maxDepth)}
+	}
+	return s.resolve().process(context.WithValue(ctx, lazyDepthKey{}, depth), ptr)
+}
+
+// ForType doesn't resolve inner schema, hence it's safe to be called during construction of recursive schemas
+func (LazySchema[T]) ForType() reflect.Type { return reflect.TypeFor[T]() }
+
+func (LazySchema[T]) typed(T) {}
+
+// describe resolves inner schema unless lazy schema of the same type is being described up the tree
+// or describer keeps lazy schemas unresolved
+func (s LazySchema[T]) describe(d *describer) Node {
+	node := Node{Kind: KindLazy, Type: reflect.TypeFor[T]().String(), MaxDepth: s.maxDepth}
+	key := lazyDescribeKey{reflect.TypeFor[T]()}
+	if d.unresolved {
+		return node
+	}
+	if !d.enter(key) {
+		node.Recursive = true
+		return node
+	}
+	defer d.leave(key)
+
+	elem := s.Resolve().describe(d)
+	node.Elem = &elem
+	return node
+}
+
+// Resolve returns inner schema constructing it on first call.
+// Introspection walking schemas must track resolved ones to avoid infinite recursion
+func (s LazySchema[T]) Resolve() Schema { return s.resolve() }
+
+type lazyDescribeKey struct{ typ reflect.Type }
+
+type lazyDepthKey struct{}
@@ -0,0 +1,34 @@
+package ecto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/egsam98/ecto"
+	ectos "github.com/egsam98/ecto/strings"
+)
+
+type Node struct {
+	Name     string
+	Children []Node
+}
+
+func TestLazy(t *testing.T) {
+	var schema ecto.StructSchema[Node]
+	schema = ecto.Struct[Node](ecto.M{
+		"Name":     ecto.String().Required(),
+		"Children": ecto.Slice[[]Node](ecto.Lazy[Node](func() ecto.Schema { return schema }).MaxDepth(2)),
+	})
+
+	assert.NoError(t, schema.Process(&Node{Name: "a", Children: []Node{{Name: "b"}}}))
+	assert.EqualError(t, schema.Process(&Node{Name: "a", Children: []Node{{Name: "b", Children: []Node{{}}}}}),
+		`{"Children":{"0":{"Children":{"0":{"Name":["required"]}}}}}`)
+	assert.EqualError(t, schema.Process(&Node{Children: []Node{{Children: []Node{{Children: []Node{{}}}}}}}),
+		`{"Children":{"0":{"Children":{"0":{"Children":{"0":["exceeds maximum nesting depth of 2"]},"Name":["required"]}},"Name":["required"]}},"Name":["required"]}`)
+
+	t.Run("type mismatch", func(t *testing.T) {
+		schema := ecto.Lazy[Node](func() ecto.Schema { return ecto.String().Test(ectos.Min(1)) })
+		assert.Panics(t, func() { _ = schema.Process(&Node{}) })
+	})
+}
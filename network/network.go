@@ -0,0 +1,242 @@
+package network
+
+import (
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/egsam98/errors"
+	"github.com/samber/lo"
+	"golang.org/x/exp/constraints"
+	"golang.org/x/net/idna"
+
+	"github.com/egsam98/ecto"
+)
+
+// hostProfile converts IDN host to ASCII validating it per IDNA2008, RFC 1123 and DNS length limits
+var hostProfile = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.VerifyDNSLength(true))
+
+// IPv4 validates string as IPv4 address in dotted decimal form
+func IPv4() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "network.IPv4",
+		Error: "invalid IPv4 address",
+		Func: func(v *string) bool {
+			addr, err := netip.ParseAddr(*v)
+			return err == nil && addr.Is4()
+		},
+	}
+}
+
+// IPv6 validates string as IPv6 address. IPv4-mapped ones (ex. "::ffff:10.0.0.1") are rejected, so IPv4 and IPv6
+// don't overlap
+func IPv6() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "network.IPv6",
+		Error: "invalid IPv6 address",
+		Func: func(v *string) bool {
+			addr, err := netip.ParseAddr(*v)
+			return err == nil && addr.Is6() && !addr.Is4In6()
+		},
+	}
+}
+
+// CIDR validates string as IP prefix in CIDR notation, ex. "10.0.0.0/8"
+func CIDR() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "network.CIDR",
+		Error: "invalid CIDR",
+		Func: func(v *string) bool {
+			_, err := netip.ParsePrefix(*v)
+			return err == nil
+		},
+	}
+}
+
+// Hostname validates RFC 1123 host name: dot-separated labels of letters, digits and hyphens.
+// Internationalized names are accepted via IDNA, ex. "пример.рф"
+func Hostname() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "network.Hostname",
+		Error: "invalid hostname",
+		Func: func(v *string) bool {
+			_, ok := asciiHost(*v)
+			return ok
+		},
+	}
+}
+
+// FQDN validates fully qualified domain name: Hostname with at least two labels and non-numeric top-level domain
+func FQDN() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "network.FQDN",
+		Error: "invalid fully qualified domain name",
+		Func: func(v *string) bool {
+			host, ok := asciiHost(*v)
+			if !ok {
+				return false
+			}
+			dot := strings.LastIndexByte(host, '.')
+			return dot > 0 && strings.Trim(host[dot+1:], "0123456789") != ""
+		},
+	}
+}
+
+// MAC validates IEEE 802 MAC-48, EUI-48, EUI-64 or 20-octet IP over InfiniBand link-layer address (see net.ParseMAC)
+func MAC() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "network.MAC",
+		Error: "invalid MAC address",
+		Func: func(v *string) bool {
+			_, err := net.ParseMAC(*v)
+			return err == nil
+		},
+	}
+}
+
+// HostPort validates "host:port" pair, where host is either IP address or Hostname and port is Port
+func HostPort() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "network.HostPort",
+		Error: "invalid host:port",
+		Func: func(v *string) bool {
+			host, port, err := net.SplitHostPort(*v)
+			if err != nil {
+				return false
+			}
+			if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+				return false
+			}
+			if _, err := netip.ParseAddr(host); err == nil {
+				return true
+			}
+			_, ok := asciiHost(host)
+			return ok
+		},
+	}
+}
+
+// Port restricts integer to TCP/UDP port range 1-65535
+func Port[T constraints.Integer]() ecto.Test[T] {
+	return ecto.Test[T]{
+		Code:  "network.Port",
+		Error: "must be a port between 1 and 65535",
+		Func:  func(v *T) bool { return *v >= 1 && uint64(*v) <= 65535 },
+	}
+}
+
+// Is4 restricts IP address to IPv4
+func Is4() ecto.Test[netip.Addr] {
+	return ecto.Test[netip.Addr]{
+		Code:  "network.Is4",
+		Error: "must be IPv4 address",
+		Func:  func(v *netip.Addr) bool { return v.Is4() },
+	}
+}
+
+// Is6 restricts IP address to IPv6 except for IPv4-mapped ones (see IPv6)
+func Is6() ecto.Test[netip.Addr] {
+	return ecto.Test[netip.Addr]{
+		Code:  "network.Is6",
+		Error: "must be IPv6 address",
+		Func:  func(v *netip.Addr) bool { return v.Is6() && !v.Is4In6() },
+	}
+}
+
+// Private restricts IP address to private networks (RFC 1918, RFC 4193)
+func Private() ecto.Test[netip.Addr] {
+	return ecto.Test[netip.Addr]{
+		Code:  "network.Private",
+		Error: "must be private IP address",
+		Func:  func(v *netip.Addr) bool { return v.Unmap().IsPrivate() },
+	}
+}
+
+// Public restricts IP address to globally reachable unicast ones, i.e. not in IANA special-purpose ranges:
+// private, shared (CGNAT), loopback, link-local, documentation, benchmarking, multicast, reserved etc.
+func Public() ecto.Test[netip.Addr] {
+	return ecto.Test[netip.Addr]{
+		Code:  "network.Public",
+		Error: "must be public IP address",
+		Func:  func(v *netip.Addr) bool { return IsPublic(*v) },
+	}
+}
+
+// Loopback restricts IP address to loopback ones (ex. 127.0.0.1, ::1)
+func Loopback() ecto.Test[netip.Addr] {
+	return ecto.Test[netip.Addr]{
+		Code:  "network.Loopback",
+		Error: "must be loopback IP address",
+		Func:  func(v *netip.Addr) bool { return v.Unmap().IsLoopback() },
+	}
+}
+
+// PrefixBits restricts prefix length of CIDR with both inclusive bounds
+func PrefixBits(min, max int) ecto.Test[netip.Prefix] {
+	if min > max {
+		panic(errors.Errorf("invalid range [%d, %d]", min, max))
+	}
+	return ecto.Test[netip.Prefix]{
+		Code:   "network.PrefixBits",
+		Params: map[string]any{"min": min, "max": max},
+		Error:  ecto.Errorf("prefix length must be between %d and %d", min, max),
+		Func:   func(v *netip.Prefix) bool { return v.Bits() >= min && v.Bits() <= max },
+	}
+}
+
+// specialPrefixes aren't globally reachable per IANA IPv4 and IPv6 Special-Purpose Address Registries (RFC 6890).
+// IPv6 prefixes embedding IPv4 addresses (NAT64, 6to4, Teredo) are included, so they can't bypass Public
+var specialPrefixes = lo.Map([]string{
+	"0.0.0.0/8",       // "This network"
+	"10.0.0.0/8",      // Private-Use
+	"100.64.0.0/10",   // Shared Address Space (CGNAT)
+	"127.0.0.0/8",     // Loopback
+	"169.254.0.0/16",  // Link Local
+	"172.16.0.0/12",   // Private-Use
+	"192.0.0.0/24",    // IETF Protocol Assignments
+	"192.0.2.0/24",    // Documentation (TEST-NET-1)
+	"192.88.99.0/24",  // 6to4 Relay Anycast
+	"192.168.0.0/16",  // Private-Use
+	"198.18.0.0/15",   // Benchmarking
+	"198.51.100.0/24", // Documentation (TEST-NET-2)
+	"203.0.113.0/24",  // Documentation (TEST-NET-3)
+	"224.0.0.0/4",     // Multicast
+	"240.0.0.0/4",     // Reserved, including Limited Broadcast
+	"::/128",          // Unspecified
+	"::1/128",         // Loopback
+	"64:ff9b::/96",    // IPv4-IPv6 Translation (NAT64)
+	"64:ff9b:1::/48",  // Local-Use IPv4/IPv6 Translation
+	"100::/64",        // Discard-Only
+	"2001::/23",       // IETF Protocol Assignments, including Teredo
+	"2001:db8::/32",   // Documentation
+	"2002::/16",       // 6to4
+	"3fff::/20",       // Documentation
+	"5f00::/16",       // Segment Routing (SRv6) SIDs
+	"fc00::/7",        // Unique-Local
+	"fe80::/10",       // Link-Local Unicast
+	"ff00::/8",        // Multicast
+}, func(prefix string, _ int) netip.Prefix { return netip.MustParsePrefix(prefix) })
+
+// IsPublic reports whether IP address is globally reachable unicast one (see Public)
+func IsPublic(addr netip.Addr) bool {
+	addr = addr.Unmap().WithZone("")
+	if !addr.IsValid() {
+		return false
+	}
+	for _, prefix := range specialPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// asciiHost converts host to ASCII form validating it
+func asciiHost(host string) (string, bool) {
+	if host == "" {
+		return "", false
+	}
+	ascii, err := hostProfile.ToASCII(strings.TrimSuffix(host, "."))
+	return ascii, err == nil
+}
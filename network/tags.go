@@ -0,0 +1,16 @@
+package network
+
+import "github.com/egsam98/ecto"
+
+// Tags for ecto.FromTags
+func init() {
+	ecto.RegisterTag("ipv4", ecto.NoParamTag(IPv4))
+	ecto.RegisterTag("ipv6", ecto.NoParamTag(IPv6))
+	ecto.RegisterTag("cidr", ecto.NoParamTag(CIDR))
+	ecto.RegisterTag("hostname", ecto.NoParamTag(Hostname))
+	ecto.RegisterTag("fqdn", ecto.NoParamTag(FQDN))
+	ecto.RegisterTag("mac", ecto.NoParamTag(MAC))
+	ecto.RegisterTag("hostport", ecto.NoParamTag(HostPort))
+	ecto.RegisterTag("port", ecto.NoParamTag(Port[int64]))
+	ecto.RegisterTag("port", ecto.NoParamTag(Port[uint64]))
+}
@@ -0,0 +1,102 @@
+package ecto_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/egsam98/ecto"
+	"github.com/egsam98/ecto/network"
+)
+
+func TestIsPublic(t *testing.T) {
+	for addr, public := range map[string]bool{
+		"8.8.8.8":                true,
+		"1.1.1.1":                true,
+		"100.63.255.255":         true,
+		"100.128.0.0":            true,
+		"198.17.255.255":         true,
+		"198.20.0.0":             true,
+		"2606:4700:4700::1111":   true,
+		"::ffff:8.8.8.8":         true,
+		"0.0.0.0":                false,
+		"0.1.2.3":                false,
+		"10.0.0.1":               false,
+		"100.64.0.1":             false,
+		"100.127.255.255":        false,
+		"127.0.0.1":              false,
+		"169.254.169.254":        false,
+		"172.16.0.1":             false,
+		"192.0.0.8":              false,
+		"192.0.2.1":              false,
+		"192.88.99.1":            false,
+		"192.168.1.1":            false,
+		"198.18.0.1":             false,
+		"198.19.255.255":         false,
+		"198.51.100.1":           false,
+		"203.0.113.1":            false,
+		"224.0.0.1":              false,
+		"240.0.0.1":              false,
+		"255.255.255.255":        false,
+		"::":                     false,
+		"::1":                    false,
+		"::ffff:127.0.0.1":       false,
+		"::ffff:169.254.169.254": false,
+		"64:ff9b::a9fe:a9fe":     false,
+		"64:ff9b:1::1":           false,
+		"100::1":                 false,
+		"2001::1":                false,
+		"2001:db8::1":            false,
+		"2002:a9fe:a9fe::1":      false,
+		"3fff::1":                false,
+		"5f00::1":                false,
+		"fd00::1":                false,
+		"fe80::1%eth0":           false,
+		"ff02::1":                false,
+	} {
+		assert.Equal(t, public, network.IsPublic(netip.MustParseAddr(addr)), addr)
+	}
+	assert.False(t, network.IsPublic(netip.Addr{}))
+}
+
+func TestNetworkStrings(t *testing.T) {
+	for _, tc := range []struct {
+		test   ecto.Test[string]
+		values map[string]bool
+	}{
+		{test: network.IPv4(), values: map[string]bool{
+			"10.0.0.1": true, "::ffff:10.0.0.1": false, "2001:db8::1": false, "010.0.0.1": false, "": false,
+		}},
+		{test: network.IPv6(), values: map[string]bool{
+			"2001:db8::1": true, "::1": true, "fe80::1%eth0": true,
+			"::ffff:10.0.0.1": false, "::ffff:a00:1": false, "10.0.0.1": false, "": false,
+		}},
+		{test: network.CIDR(), values: map[string]bool{
+			"10.0.0.0/8": true, "2001:db8::/32": true, "10.0.0.1/32": true,
+			"10.0.0.0": false, "10.0.0.0/33": false, "2001:db8::/129": false, "": false,
+		}},
+		{test: network.Hostname(), values: map[string]bool{
+			"example.com": true, "localhost": true, "api-1.example.com.": true, "пример.рф": true,
+			"-example.com": false, "exa mple.com": false, "exa_mple.com": false, "": false, "a..b": false,
+		}},
+		{test: network.FQDN(), values: map[string]bool{
+			"example.com": true, "api.example.org.": true, "пример.рф": true,
+			"localhost": false, "example.123": false, "192.168.0.1": false, ".com": false,
+		}},
+		{test: network.MAC(), values: map[string]bool{
+			"00:00:5e:00:53:01": true, "00-00-5E-00-53-01": true, "0000.5e00.5301": true,
+			"02:00:5e:10:00:00:00:01": true,
+			"00:00:5e:00:53":          false, "00:00:5e:00:53:zz": false, "": false,
+		}},
+		{test: network.HostPort(), values: map[string]bool{
+			"example.com:8080": true, "192.0.2.1:443": true, "[2001:db8::1]:443": true, "localhost:1": true,
+			"example.com": false, "example.com:0": false, "example.com:65536": false, "2001:db8::1:443": false,
+			"exa_mple.com:80": false, ":80": false,
+		}},
+	} {
+		for value, valid := range tc.values {
+			assert.Equal(t, valid, tc.test.Run(&value) == nil, "%s(%q)", tc.test.Code, value)
+		}
+	}
+}
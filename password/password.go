@@ -0,0 +1,281 @@
+package password
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/egsam98/errors"
+	"github.com/samber/lo"
+
+	"github.com/egsam98/ecto"
+)
+
+// sequences are sources of easily guessed substrings checked in both directions
+var sequences = []string{
+	"abcdefghijklmnopqrstuvwxyz",
+	"01234567890",
+	"qwertyuiop", "asdfghjkl", "zxcvbnm",
+	"йцукенгшщзхъ", "фывапролджэ", "ячсмитьбю",
+}
+
+// Policy describes a set of password requirements, zero values disable the corresponding ones
+type Policy struct {
+	MinLength      uint
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+	// MaxRepeated restricts number of identical characters in a row
+	MaxRepeated uint
+	// MinSequence forbids alphabetical, numerical or keyboard sequences of this length or longer (ex. 4 for "1234")
+	MinSequence uint
+	// Fields are names of sibling struct fields which values must not be contained (ex. "Username", "Email")
+	Fields []string
+	// MinEntropy restricts estimated strength in bits (see Entropy)
+	MinEntropy float64
+}
+
+// Tests returns tests for every enabled requirement, hence every failure yields its own error
+func (p Policy) Tests() []ecto.Test[string] {
+	var tests []ecto.Test[string]
+	if p.MinLength > 0 {
+		tests = append(tests, MinLength(p.MinLength))
+	}
+	if p.RequireUpper {
+		tests = append(tests, RequireUpper())
+	}
+	if p.RequireLower {
+		tests = append(tests, RequireLower())
+	}
+	if p.RequireDigit {
+		tests = append(tests, RequireDigit())
+	}
+	if p.RequireSpecial {
+		tests = append(tests, RequireSpecial())
+	}
+	if p.MaxRepeated > 0 {
+		tests = append(tests, MaxRepeated(p.MaxRepeated))
+	}
+	if p.MinSequence > 0 {
+		tests = append(tests, NoSequences(p.MinSequence))
+	}
+	if len(p.Fields) > 0 {
+		tests = append(tests, NotContainingFields(p.Fields...))
+	}
+	if p.MinEntropy > 0 {
+		tests = append(tests, MinEntropy(p.MinEntropy))
+	}
+	return tests
+}
+
+// MinLength restricts password length in characters with a lower inclusive bound
+func MinLength(length uint) ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:   "password.MinLength",
+		Params: map[string]any{"length": length},
+		Error:  ecto.Errorf("password must be at least %d characters long", length),
+		Func:   func(v *string) bool { return utf8.RuneCountInString(*v) >= int(length) },
+	}
+}
+
+// RequireUpper requires at least one uppercase letter
+func RequireUpper() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "password.RequireUpper",
+		Error: "password must contain an uppercase letter",
+		Func:  func(v *string) bool { return strings.IndexFunc(*v, unicode.IsUpper) >= 0 },
+	}
+}
+
+// RequireLower requires at least one lowercase letter
+func RequireLower() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "password.RequireLower",
+		Error: "password must contain a lowercase letter",
+		Func:  func(v *string) bool { return strings.IndexFunc(*v, unicode.IsLower) >= 0 },
+	}
+}
+
+// RequireDigit requires at least one digit
+func RequireDigit() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "password.RequireDigit",
+		Error: "password must contain a digit",
+		Func:  func(v *string) bool { return strings.IndexFunc(*v, unicode.IsDigit) >= 0 },
+	}
+}
+
+// RequireSpecial requires at least one character being neither letter, digit nor whitespace
+func RequireSpecial() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "password.RequireSpecial",
+		Error: "password must contain a special character",
+		Func:  func(v *string) bool { return strings.IndexFunc(*v, isSpecial) >= 0 },
+	}
+}
+
+// MaxRepeated restricts number of identical characters in a row (ex. 2 forbids "aaa")
+func MaxRepeated(count uint) ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:   "password.MaxRepeated",
+		Params: map[string]any{"count": count},
+		Error:  ecto.Errorf("password must not contain more than %d identical characters in a row", count),
+		Func: func(v *string) bool {
+			var prev rune
+			var repeated uint
+			for _, r := range *v {
+				if r == prev {
+					repeated++
+				} else {
+					prev, repeated = r, 1
+				}
+				if repeated > count {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+// NoSequences forbids alphabetical, numerical or keyboard sequences (ex. "abcd", "4321", "qwerty") of length
+// or longer, case-insensitive
+func NoSequences(length uint) ecto.Test[string] {
+	if length < 2 {
+		panic(errors.Errorf("sequence length must be at least 2, got %d", length))
+	}
+
+	var forbidden []string
+	for _, seq := range sequences {
+		runes := []rune(seq)
+		reversed := slices.Clone(runes)
+		slices.Reverse(reversed)
+		for i := 0; i+int(length) <= len(runes); i++ {
+			forbidden = append(forbidden, string(runes[i:i+int(length)]), string(reversed[i:i+int(length)]))
+		}
+	}
+
+	return ecto.Test[string]{
+		Code:   "password.NoSequences",
+		Params: map[string]any{"length": length},
+		Error:  ecto.Errorf("password must not contain sequences of %d characters like 1234 or qwerty", length),
+		Func: func(v *string) bool {
+			lower := strings.ToLower(*v)
+			return !lo.SomeBy(forbidden, func(seq string) bool { return strings.Contains(lower, seq) })
+		},
+	}
+}
+
+// NotContainingFields forbids password to contain values of sibling string fields of the struct processed
+// by ecto.StructSchema, case-insensitive. Local part of email is checked separately. Values shorter than
+// 3 characters and nil pointers are ignored. ecto.Struct panics if struct has no string field or pointer
+// to it with one of names
+func NotContainingFields(names ...string) ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:   "password.NotContainingFields",
+		Params: map[string]any{"names": names},
+		Error:  ecto.Error("password must not contain " + strings.Join(names, ", ")),
+		FuncContext: func(ctx context.Context, v *string) bool {
+			parent := ecto.Parent(ctx)
+			if parent == nil {
+				return true
+			}
+
+			lower := strings.ToLower(*v)
+			rv := reflect.Indirect(reflect.ValueOf(parent))
+			for _, name := range names {
+				field := reflect.Indirect(rv.FieldByName(name))
+				if !field.IsValid() || field.Kind() != reflect.String {
+					continue
+				}
+				value := strings.ToLower(field.String())
+				local, _, _ := strings.Cut(value, "@")
+				for _, part := range []string{value, local} {
+					if utf8.RuneCountInString(part) >= 3 && strings.Contains(lower, part) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		CheckParent: func(typ reflect.Type) error {
+			for _, name := range names {
+				if field, ok := typ.FieldByName(name); !ok || !isString(field.Type) {
+					return errors.Errorf("%s has no string field %s", typ, name)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// MinEntropy restricts estimated password strength in bits (see Entropy). Common guidelines are 50 bits for
+// online services and 80+ for sensitive ones
+func MinEntropy(bits float64) ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:   "password.MinEntropy",
+		Params: map[string]any{"bits": bits},
+		Error:  "password is too weak",
+		Func:   func(v *string) bool { return Entropy(*v) >= bits },
+	}
+}
+
+// Entropy estimates password strength in bits as length multiplied by log2 of character pool size,
+// where pool consists of character classes used (lowercase, uppercase, digits, special, other letters).
+// Repeated characters don't add strength
+func Entropy(password string) float64 {
+	var pool int
+	var lower, upper, digit, special, other bool
+	for _, r := range password {
+		switch {
+		case r < utf8.RuneSelf && unicode.IsLower(r):
+			lower = true
+		case r < utf8.RuneSelf && unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case isSpecial(r):
+			special = true
+		default:
+			other = true
+		}
+	}
+	for _, class := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {special, 33}, {other, 100}} {
+		if class.used {
+			pool += class.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+
+	var length int
+	var prev rune
+	for _, r := range password {
+		if r != prev {
+			length++
+		}
+		prev = r
+	}
+	return float64(length) * math.Log2(float64(pool))
+}
+
+// isString reports whether field of type typ is a string or pointer to it
+func isString(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.String
+}
+
+func isSpecial(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r)
+}
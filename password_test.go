@@ -0,0 +1,148 @@
+package ecto_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/egsam98/ecto"
+	"github.com/egsam98/ecto/password"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		policy password.Policy
+		value  string
+		valid  bool
+	}{
+		{policy: password.Policy{MinLength: 8}, value: "abcdefgh", valid: true},
+		{policy: password.Policy{MinLength: 8}, value: "abcdefg"},
+		{policy: password.Policy{MinLength: 6}, value: "пароль", valid: true}, // 12 bytes
+		{policy: password.Policy{MinLength: 7}, value: "пароль"},
+		{policy: password.Policy{RequireUpper: true}, value: "abcD", valid: true},
+		{policy: password.Policy{RequireUpper: true}, value: "Пароль", valid: true},
+		{policy: password.Policy{RequireUpper: true}, value: "abc1!"},
+		{policy: password.Policy{RequireLower: true}, value: "ABCd", valid: true},
+		{policy: password.Policy{RequireLower: true}, value: "ABC1!"},
+		{policy: password.Policy{RequireDigit: true}, value: "abc1", valid: true},
+		{policy: password.Policy{RequireDigit: true}, value: "abc!"},
+		{policy: password.Policy{RequireSpecial: true}, value: "abc!", valid: true},
+		{policy: password.Policy{RequireSpecial: true}, value: "abc_", valid: true},
+		{policy: password.Policy{RequireSpecial: true}, value: "abc 1"},
+		{policy: password.Policy{MaxRepeated: 2}, value: "aabbaa", valid: true},
+		{policy: password.Policy{MaxRepeated: 2}, value: "abaaa"},
+		{policy: password.Policy{MaxRepeated: 1}, value: "abab", valid: true},
+		{policy: password.Policy{MaxRepeated: 1}, value: "abba"},
+		{policy: password.Policy{MinSequence: 4}, value: "abc1xyz", valid: true},
+		{policy: password.Policy{MinSequence: 4}, value: "xABCDx"},
+		{policy: password.Policy{MinSequence: 4}, value: "x4321x"},
+		{policy: password.Policy{MinSequence: 4}, value: "Qwerty"},
+		{policy: password.Policy{MinSequence: 4}, value: "ЙЦУК"},
+		{policy: password.Policy{MinSequence: 3}, value: "x890x"},
+		{policy: password.Policy{MinEntropy: 50}, value: "Tr0ub4dor&3", valid: true},
+		{policy: password.Policy{MinEntropy: 50}, value: "password"},
+		{policy: password.Policy{MinEntropy: 50}, value: "Aaaaaaaaaaaaaaaaaaaa1!"}, // Repeats don't add strength
+		{
+			policy: password.Policy{MinLength: 8, RequireUpper: true, RequireDigit: true, MaxRepeated: 2, MinEntropy: 40},
+			value:  "Correct7Horse",
+			valid:  true,
+		},
+	} {
+		test := ecto.Atomic[string]().Test(tc.policy.Tests()...)
+		assert.Equal(t, tc.valid, test.Process(&tc.value) == nil, "%+v %q", tc.policy, tc.value)
+	}
+
+	assert.Empty(t, password.Policy{}.Tests())
+	assert.Equal(t, []string{
+		"password.MinLength",
+		"password.RequireUpper",
+		"password.RequireLower",
+		"password.RequireDigit",
+		"password.RequireSpecial",
+		"password.MaxRepeated",
+		"password.NoSequences",
+		"password.NotContainingFields",
+		"password.MinEntropy",
+	}, lo.Map(password.Policy{
+		MinLength:      1,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+		MaxRepeated:    1,
+		MinSequence:    2,
+		Fields:         []string{"Email"},
+		MinEntropy:     1,
+	}.Tests(), func(test ecto.Test[string], _ int) string { return test.Code }))
+	assert.Panics(t, func() { password.NoSequences(1) })
+}
+
+func TestPasswordNotContainingFields(t *testing.T) {
+	type Account struct {
+		Username string
+		Email    *string
+		Age      int
+		Password string
+	}
+	schema := ecto.Struct[Account](ecto.M{
+		"Password": ecto.String().Test(password.NotContainingFields("Username", "Email")),
+	})
+
+	for _, tc := range []struct {
+		account Account
+		valid   bool
+	}{
+		{account: Account{Username: "johnny", Email: lo.ToPtr("john@example.com"), Password: "correct horse"}, valid: true},
+		{account: Account{Username: "johnny", Password: "my-JOHNNY-pass"}},
+		{account: Account{Email: lo.ToPtr("john@example.com"), Password: "John!2024"}},
+		{account: Account{Email: lo.ToPtr("john@example.com"), Password: "x-john@example.com"}},
+		{account: Account{Username: "jo", Password: "jo-jo-jo"}, valid: true}, // Short values are ignored
+		{account: Account{Password: "anything"}, valid: true},                 // Nil email
+	} {
+		assert.Equal(t, tc.valid, schema.Process(&tc.account) == nil, tc.account.Password)
+	}
+
+	// Outside of struct there is nothing to compare with
+	value := "johnny"
+	test := password.NotContainingFields("Username")
+	assert.Nil(t, test.Run(&value))
+
+	for _, names := range [][]string{{"Login"}, {"Age"}} {
+		assert.Panics(t, func() {
+			ecto.Struct[Account](ecto.M{"Password": ecto.String().Test(password.NotContainingFields(names...))})
+		}, names)
+		assert.Panics(t, func() {
+			ecto.Struct[Account](ecto.M{
+				"Email": ecto.Ptr[string](ecto.String().Test(password.NotContainingFields(names...))),
+			})
+		}, names)
+	}
+}
+
+func TestPasswordMinEntropy(t *testing.T) {
+	for value, bits := range map[string]float64{
+		"":            0,
+		"aaaa":        math.Log2(26),
+		"abcd":        4 * math.Log2(26),
+		"abAB":        4 * math.Log2(52),
+		"aA1!":        4 * math.Log2(95),
+		"Tr0ub4dor&3": 11 * math.Log2(95),
+		"пароль":      6 * math.Log2(100),
+		"пароль1":     7 * math.Log2(110),
+	} {
+		assert.InDelta(t, bits, password.Entropy(value), 1e-9, value)
+	}
+
+	test := password.MinEntropy(50)
+	for value, valid := range map[string]bool{
+		"Tr0ub4dor&3": true,
+		"aA1!aA1!":    true,  // 52.6 bits
+		"aA1!aA1":     false, // 46 bits
+		"password":    false,
+		"":            false,
+	} {
+		assert.Equal(t, valid, test.Run(&value) == nil, value)
+	}
+}
@@ -1,6 +1,7 @@
 package ecto
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/pkg/errors"
@@ -8,13 +9,17 @@ import (
 
 var _ Schema = (*PtrSchema[any])(nil)
 var _ IAtomicOrPtrSchema = (*PtrSchema[any])(nil)
+var _ TypedSchema[*any] = (*PtrSchema[any])(nil)
 
 // PtrSchema wraps inner Schema assuming input data as pointer. Features:
 // - Mark a pointer as required (non-nil)
+// - Replace nil pointer with a freshly allocated default value
 // - Process internal schema
 type PtrSchema[To any] struct {
-	inner    Schema
-	required bool
+	inner        Schema
+	required     bool
+	defaultFunc  func(context.Context) (To, error)
+	defaultValue *To
 }
 
 func Ptr[To any](inner Schema) PtrSchema[To] {
@@ -26,27 +31,82 @@ func Ptr[To any](inner Schema) PtrSchema[To] {
 	return self
 }
 
-// Process may return ListError
-func (s PtrSchema[T]) Process(data *T) error { return s.process(&data) }
+// Process may return ListError.
+// Defaults aren't visible to the caller here since the pointer is passed by value, they're applied to pointers
+// owned by parent schemas (ex. struct fields)
+func (s PtrSchema[T]) Process(data *T) error { return s.process(context.Background(), &data) }
+
+// ProcessContext is Process with context passed to default providers (see DefaultContext) and inner schema
+func (s PtrSchema[T]) ProcessContext(ctx context.Context, data *T) error {
+	return s.process(ctx, &data)
+}
 
 func (s PtrSchema[T]) Required() PtrSchema[T] {
 	s.required = true
 	return s
 }
 
-func (s PtrSchema[T]) process(ptrAny any) error {
-	ptr := *ptrAny.(**T)
-	if ptr == nil {
+// Default allocates a copy of value for every nil pointer
+func (s PtrSchema[T]) Default(value T) PtrSchema[T] {
+	s.defaultFunc = func(context.Context) (T, error) { return value, nil }
+	s.defaultValue = &value
+	return s
+}
+
+// DefaultNew allocates Go zero-value for every nil pointer, so inner schema is able to fill its own defaults
+func (s PtrSchema[T]) DefaultNew() PtrSchema[T] {
+	return s.Default(*new(T))
+}
+
+// DefaultFunc allocates value generated on every Process call for nil pointer.
+// Use DefaultContext for generators that may fail
+func (s PtrSchema[T]) DefaultFunc(fn func() T) PtrSchema[T] {
+	s.defaultFunc = func(context.Context) (T, error) { return fn(), nil }
+	s.defaultValue = nil
+	return s
+}
+
+// DefaultContext allocates value generated on every Process call with context passed to ProcessContext
+// for nil pointer. Generator error fails processing
+func (s PtrSchema[T]) DefaultContext(fn func(ctx context.Context) (T, error)) PtrSchema[T] {
+	s.defaultFunc = fn
+	s.defaultValue = nil
+	return s
+}
+
+func (s PtrSchema[T]) process(ctx context.Context, ptrAny any) error {
+	ptrPtr := ptrAny.(**T)
+	if *ptrPtr == nil {
 		if s.required {
 			return ListError{errRequired}
 		}
-		return nil
+		if s.defaultFunc == nil {
+			return nil
+		}
+		value, err := s.defaultFunc(ctx)
+		if err != nil {
+			return ListError{Error(err.Error())}
+		}
+		*ptrPtr = &value
 	}
-	return s.inner.process(ptr)
+	return s.inner.process(ctx, *ptrPtr)
 }
 
 func (s PtrSchema[T]) ForType() reflect.Type { return reflect.TypeFor[*T]() }
 
+func (PtrSchema[T]) typed(*T) {}
+
+func (s PtrSchema[T]) describe(d *describer) Node {
+	elem := s.inner.describe(d)
+	return Node{
+		Kind:     KindPtr,
+		Type:     reflect.TypeFor[*T]().String(),
+		Required: s.required,
+		Default:  newDefaultNode(s.defaultFunc != nil, s.defaultValue),
+		Elem:     &elem,
+	}
+}
+
 func (s PtrSchema[T]) IsRequired() bool { return s.required }
 
 func (s PtrSchema[To]) WithRequired(value bool) IAtomicOrPtrSchema {
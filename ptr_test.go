@@ -1,7 +1,9 @@
 package ecto_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/samber/lo"
@@ -26,3 +28,39 @@ func TestPtr_Process(t *testing.T) {
 		assert.EqualError(t, schema.Process(nil), `["required"]`)
 	})
 }
+
+func TestPtrSchema_Default(t *testing.T) {
+	type Config struct {
+		Port int
+	}
+	schema := ecto.Struct[struct{ Config *Config }](ecto.M{
+		"Config": ecto.Ptr[Config](ecto.Struct[Config](ecto.M{
+			"Port": ecto.Int().Default(8080),
+		})).DefaultNew(),
+	})
+
+	var v1, v2 struct{ Config *Config }
+	assert.NoError(t, schema.Process(&v1))
+	assert.NoError(t, schema.Process(&v2))
+	assert.Equal(t, &Config{Port: 8080}, v1.Config)
+	assert.NotSame(t, v1.Config, v2.Config)
+
+	t.Run("context", func(t *testing.T) {
+		type key struct{}
+		schema := ecto.Struct[struct{ Port *int }](ecto.M{
+			"Port": ecto.Ptr[int](ecto.Int()).DefaultContext(func(ctx context.Context) (int, error) {
+				port, ok := ctx.Value(key{}).(int)
+				if !ok {
+					return 0, errors.New("no port in context")
+				}
+				return port, nil
+			}),
+		})
+
+		v, err := schema.CastJSON([]byte(`{}`), ecto.Context(context.WithValue(context.Background(), key{}, 80)))
+		assert.NoError(t, err)
+		assert.Equal(t, lo.ToPtr(80), v.Port)
+		_, err = schema.CastJSON([]byte(`{}`))
+		assert.EqualError(t, err, `{"Port":["no port in context"]}`)
+	})
+}
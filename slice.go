@@ -1,14 +1,17 @@
 package ecto
 
 import (
+	"context"
 	"reflect"
 	"strconv"
 
 	"github.com/egsam98/errors"
+	"github.com/samber/lo"
 )
 
 var _ Schema = (*SliceSchema[[]any, any])(nil)
 var _ ISliceSchema = (*SliceSchema[[]any, any])(nil)
+var _ TypedSchema[[]any] = (*SliceSchema[[]any, any])(nil)
 
 // SliceSchema wraps inner Schema assuming input data as slice. Features:
 // - Run slice-specific tests (see ecto/slices subpackage)
@@ -34,14 +37,19 @@ func (s SliceSchema[S, T]) Test(tests ...Test[S]) SliceSchema[S, T] {
 
 // Process may return ListError (for list tests) or MapError for individual element errors.
 // Map key is a stringified slice index
-func (s SliceSchema[S, T]) Process(data []T) error { return s.process(&data) }
+func (s SliceSchema[S, T]) Process(data []T) error { return s.process(context.Background(), &data) }
 
-func (s SliceSchema[S, T]) process(ptrAny any) error {
+// ProcessContext is Process with context passed to inner schema
+func (s SliceSchema[S, T]) ProcessContext(ctx context.Context, data []T) error {
+	return s.process(ctx, &data)
+}
+
+func (s SliceSchema[S, T]) process(ctx context.Context, ptrAny any) error {
 	ptr := ptrAny.(*S)
 
 	var errs ListError
 	for _, test := range s.tests {
-		if err := test.Run(ptr); err != nil {
+		if err := test.RunContext(ctx, ptr); err != nil {
 			errs = append(errs, *err)
 		}
 	}
@@ -50,8 +58,8 @@ func (s SliceSchema[S, T]) process(ptrAny any) error {
 	}
 
 	var innerErrs MapError
-	for i, elem := range *ptr {
-		if err := s.inner.process(&elem); err != nil {
+	for i := range *ptr {
+		if err := s.inner.process(ctx, &(*ptr)[i]); err != nil {
 			innerErrs.Add(strconv.Itoa(i), err)
 		}
 	}
@@ -63,6 +71,18 @@ func (s SliceSchema[S, T]) process(ptrAny any) error {
 
 func (SliceSchema[S, T]) ForType() reflect.Type { return reflect.TypeFor[S]() }
 
+func (SliceSchema[S, T]) typed(S) {}
+
+func (s SliceSchema[S, T]) describe(d *describer) Node {
+	elem := s.inner.describe(d)
+	return Node{
+		Kind:  KindSlice,
+		Type:  reflect.TypeFor[S]().String(),
+		Tests: lo.Map(s.tests, func(test Test[S], _ int) TestNode { return newTestNode(test) }),
+		Elem:  &elem,
+	}
+}
+
 func (s SliceSchema[S, T]) Inner() Schema { return s.inner }
 
 func (s SliceSchema[S, T]) WithInner(inner Schema) ISliceSchema {
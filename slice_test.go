@@ -22,3 +22,10 @@ func TestSlice_Process(t *testing.T) {
 	assert.EqualError(t, schema.Process([]string{"test", "http://wikipedia.org", ""}),
 		`{"0":["invalid URL"],"2":["invalid URL"]}`)
 }
+
+func TestSlice_ProcessURLWith(t *testing.T) {
+	schema := ecto.Slice[[]string](ecto.String().Test(ectos.URLWith(ectos.URLForbidPrivateHosts())))
+	assert.NoError(t, schema.Process([]string{"https://example.com/path//x"}))
+	assert.EqualError(t, schema.Process([]string{"http://127.0.0.1", "http://2130706433"}),
+		`{"0":["invalid URL"],"1":["invalid URL"]}`)
+}
@@ -9,16 +9,20 @@ import (
 // Min restricts slice length with a lower inclusive bound
 func Min[S ~[]T, T any](length uint) ecto.Test[S] {
 	return ecto.Test[S]{
-		Error: ecto.Errorf("must contain at least %d items", length),
-		Func:  func(v *S) bool { return len(*v) >= int(length) },
+		Code:   "slices.Min",
+		Params: map[string]any{"length": length},
+		Error:  ecto.Errorf("must contain at least %d items", length),
+		Func:   func(v *S) bool { return len(*v) >= int(length) },
 	}
 }
 
 // Max restricts slice length with an upper inclusive bound
 func Max[S ~[]T, T any](length uint) ecto.Test[S] {
 	return ecto.Test[S]{
-		Error: ecto.Errorf("must contain at most %d items", length),
-		Func:  func(v *S) bool { return len(*v) <= int(length) },
+		Code:   "slices.Max",
+		Params: map[string]any{"length": length},
+		Error:  ecto.Errorf("must contain at most %d items", length),
+		Func:   func(v *S) bool { return len(*v) <= int(length) },
 	}
 }
 
@@ -28,6 +32,7 @@ func Unique[S ~[]T, T comparable]() ecto.Test[S] { return UniqueBy[S](func(t T)
 // UniqueBy makes sure to have all slice elements unique by key function applied for every element
 func UniqueBy[S ~[]T, T any, K comparable](key func(T) K) ecto.Test[S] {
 	return ecto.Test[S]{
+		Code:  "slices.UniqueBy",
 		Error: "items must be unique",
 		Func: func(v *S) bool {
 			uniques := lo.Associate(*v, func(elem T) (K, struct{}) { return key(elem), struct{}{} })
@@ -0,0 +1,278 @@
+package ecto
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+	"gopkg.in/yaml.v3"
+)
+
+// CastYAML is Cast of YAML source. Processing error is SourceError keyed by `yaml` tags
+func (s StructSchema[T]) CastYAML(src []byte, opts ...CastOpt) (T, error) {
+	var root yaml.Node
+	data, err := s.Cast(src, func(src []byte, v any) error {
+		if err := yaml.Unmarshal(src, &root); err != nil || root.Kind == 0 {
+			return err
+		}
+		return root.Decode(v)
+	}, opts...)
+
+	mapErr, ok := err.(MapError)
+	if !ok {
+		return data, err
+	}
+	positions := map[string]position{"": {line: 1, column: 1}}
+	yamlPositions(&root, nil, positions)
+	return data, newSourceError(mapErr, reflect.TypeFor[T](), "yaml", strings.ToLower, positions, pathKey, opts)
+}
+
+// CastTOML is Cast of TOML source. Processing error is SourceError keyed by `toml` tags. Untagged fields are keyed by
+// their names, which match keys of source case-insensitively
+func (s StructSchema[T]) CastTOML(src []byte, opts ...CastOpt) (T, error) {
+	data, err := s.Cast(src, toml.Unmarshal, opts...)
+	mapErr, ok := err.(MapError)
+	if !ok {
+		return data, err
+	}
+	return data, newSourceError(mapErr, reflect.TypeFor[T](), "toml", nil, tomlPositions(src), foldedPathKey, opts)
+}
+
+// SourceError locates every message of MapError in source of CastYAML or CastTOML, ex.
+// "config.yaml:12:5: port: must be 65535 maximum"
+type SourceError struct {
+	// File is a name of source (see Filename)
+	File string
+	// Entries are ordered by position
+	Entries []SourceEntry
+	// Err is processing error keyed by tags of source format
+	Err MapError
+}
+
+// SourceEntry is a message of SourceError. Position is of invalid value or the nearest present parent of missing one
+type SourceEntry struct {
+	Line, Column int
+	Path         []string
+	Error        Error
+}
+
+func (e *SourceError) Error() string {
+	lines := make([]string, len(e.Entries))
+	for i, entry := range e.Entries {
+		lines[i] = fmt.Sprintf("%d:%d: %s: %s", entry.Line, entry.Column, strings.Join(entry.Path, "."), entry.Error)
+		if e.File != "" {
+			lines[i] = e.File + ":" + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *SourceError) Unwrap() error { return e.Err }
+
+type position struct{ line, column int }
+
+func newSourceError(
+	err MapError,
+	typ reflect.Type,
+	tagKey string,
+	defaultKey func(string) string,
+	positions map[string]position,
+	key func(path []string) string,
+	opts []CastOpt,
+) *SourceError {
+	res := &SourceError{
+		File: newCastConfig(opts).filename,
+		Err:  retagError(err, typ, tagKey, defaultKey).(MapError),
+	}
+	res.addEntries(res.Err, nil, positions, key)
+	slices.SortStableFunc(res.Entries, func(a, b SourceEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.Column, b.Column),
+			slices.Compare(a.Path, b.Path),
+		)
+	})
+	return res
+}
+
+// addEntries locates messages of err by positions keyed by key of path
+func (e *SourceError) addEntries(err error, path []string, positions map[string]position, key func([]string) string) {
+	switch err := err.(type) {
+	case MapError:
+		for k, err := range err {
+			e.addEntries(err, append(slices.Clip(path), k), positions, key)
+		}
+	case ListError:
+		pos, ok := positions[key(path)]
+		for i := len(path) - 1; !ok; i-- {
+			pos, ok = positions[key(path[:i])]
+		}
+		for _, msg := range err {
+			e.Entries = append(e.Entries, SourceEntry{Line: pos.line, Column: pos.column, Path: path, Error: msg})
+		}
+	}
+}
+
+// retagError replaces keys of struct fields in err with names of tagKey tags. Untagged fields are named by
+// defaultKey of field name if it's not nil
+func retagError(err error, typ reflect.Type, tagKey string, defaultKey func(string) string) error {
+	mapErr, ok := err.(MapError)
+	if !ok {
+		return err
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	res := make(MapError, len(mapErr))
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		for key, err := range mapErr {
+			res[key] = retagError(err, typ.Elem(), tagKey, defaultKey)
+		}
+	case reflect.Struct:
+		fields := make(map[string]reflect.StructField, typ.NumField())
+		for name, meta := range fieldsMeta(typ) {
+			field, _ := typ.FieldByName(name)
+			fields[meta.Tag] = field
+		}
+		for key, err := range mapErr {
+			field, ok := fields[key]
+			if !ok {
+				res[key] = err
+				continue
+			}
+			tag, _, _ := strings.Cut(field.Tag.Get(tagKey), ",")
+			if tag == "" || tag == "-" {
+				tag = field.Name
+				if defaultKey != nil {
+					tag = defaultKey(tag)
+				}
+			}
+			res[tag] = retagError(err, field.Type, tagKey, defaultKey)
+		}
+	default:
+		return err
+	}
+	return res
+}
+
+func pathKey(path []string) string { return strings.Join(path, "\x00") }
+
+// foldedPathKey is pathKey of case-insensitive keys
+func foldedPathKey(path []string) string { return strings.ToLower(pathKey(path)) }
+
+// yamlPositions records positions of node and its descendants by their paths. Aliases aren't followed
+func yamlPositions(node *yaml.Node, path []string, positions map[string]position) {
+	switch node.Kind {
+	case 0:
+		return
+	case yaml.DocumentNode:
+		for _, content := range node.Content {
+			yamlPositions(content, path, positions)
+		}
+		return
+	}
+
+	positions[pathKey(path)] = position{line: node.Line, column: node.Column}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			yamlPositions(node.Content[i+1], append(slices.Clip(path), node.Content[i].Value), positions)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			yamlPositions(item, append(slices.Clip(path), strconv.Itoa(i)), positions)
+		}
+	}
+}
+
+// tomlPositions records positions of values, tables and array tables by their paths (see foldedPathKey)
+func tomlPositions(src []byte) map[string]position {
+	positions := map[string]position{"": {line: 1, column: 1}}
+	var p unstable.Parser
+	p.Reset(src)
+
+	var table []string
+	// arrayTables counts elements of array tables by their paths
+	arrayTables := make(map[string]int)
+	for p.NextExpression() {
+		expr := p.Expression()
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			keys, pos := tomlKey(&p, expr.Key())
+			table = nil
+			for i, key := range keys {
+				table = append(table, key)
+				count, ok := arrayTables[pathKey(table)]
+				switch {
+				case i == len(keys)-1 && expr.Kind == unstable.ArrayTable:
+					arrayTables[pathKey(table)] = count + 1
+					table = append(table, strconv.Itoa(count))
+				case ok:
+					table = append(table, strconv.Itoa(count-1))
+				}
+			}
+			positions[foldedPathKey(table)] = pos
+		case unstable.KeyValue:
+			tomlKeyValue(&p, expr, table, positions)
+		}
+	}
+	return positions
+}
+
+func tomlKeyValue(p *unstable.Parser, kv *unstable.Node, table []string, positions map[string]position) {
+	keys, pos := tomlKey(p, kv.Key())
+	path := slices.Clip(table)
+	// Dotted keys define intermediate tables
+	for _, key := range keys[:len(keys)-1] {
+		path = append(path, key)
+		if _, ok := positions[foldedPathKey(path)]; !ok {
+			positions[foldedPathKey(path)] = pos
+		}
+	}
+	tomlValue(p, kv.Value(), append(path, keys[len(keys)-1]), pos, positions)
+}
+
+// tomlValue records position of value falling back to parent one, ex. of key, for nodes without source range
+func tomlValue(p *unstable.Parser, value *unstable.Node, path []string, parent position, positions map[string]position) {
+	pos := parent
+	if value.Raw.Length > 0 {
+		pos = tomlPosition(p, value.Raw)
+	}
+	positions[foldedPathKey(path)] = pos
+
+	var i int
+	for it := value.Children(); it.Next(); {
+		switch node := it.Node(); {
+		case value.Kind == unstable.Array && node.Kind != unstable.Comment:
+			tomlValue(p, node, append(slices.Clip(path), strconv.Itoa(i)), pos, positions)
+			i++
+		case value.Kind == unstable.InlineTable && node.Kind == unstable.KeyValue:
+			tomlKeyValue(p, node, path, positions)
+		}
+	}
+}
+
+// tomlKey returns parts of dotted key and position of the first one
+func tomlKey(p *unstable.Parser, it unstable.Iterator) ([]string, position) {
+	var keys []string
+	var pos position
+	for it.Next() {
+		if keys == nil {
+			pos = tomlPosition(p, it.Node().Raw)
+		}
+		keys = append(keys, string(it.Node().Data))
+	}
+	return keys, pos
+}
+
+func tomlPosition(p *unstable.Parser, raw unstable.Range) position {
+	start := p.Shape(raw).Start
+	return position{line: start.Line, column: start.Column}
+}
@@ -0,0 +1,147 @@
+package str
+
+import (
+	"net/mail"
+	"strings"
+
+	"golang.org/x/net/idna"
+
+	"github.com/egsam98/ecto"
+)
+
+// RFC 5321 limits
+const (
+	emailMaxLength      = 254
+	emailMaxLocalLength = 64
+)
+
+// EmailOpt configures strictness of Email test
+type EmailOpt func(*emailConfig)
+
+// EmailAllowDisplayName accepts RFC 5322 name-addr form, ex. "John <john@example.com>". Comments and folding
+// whitespace are rejected anyway
+func EmailAllowDisplayName() EmailOpt {
+	return func(cfg *emailConfig) { cfg.displayName = true }
+}
+
+// EmailAllowIDN accepts internationalized domain names and local parts (RFC 6532), ex. "user@пример.рф"
+func EmailAllowIDN() EmailOpt {
+	return func(cfg *emailConfig) { cfg.idn = true }
+}
+
+// EmailRequireTLD rejects domains without top-level domain, ex. "user@localhost"
+func EmailRequireTLD() EmailOpt {
+	return func(cfg *emailConfig) { cfg.tld = true }
+}
+
+// EmailMaxLength restricts length of address-spec (254 by default per RFC 5321)
+func EmailMaxLength(length uint) EmailOpt {
+	return func(cfg *emailConfig) { cfg.maxLength = length }
+}
+
+// EmailBlocklist rejects addresses which domain is blocked (ex. disposable mail providers).
+// Domain is passed in lowercase ASCII (punycode for IDN)
+func EmailBlocklist(blocked func(domain string) bool) EmailOpt {
+	return func(cfg *emailConfig) { cfg.blocked = blocked }
+}
+
+// domainProfile converts domain to ASCII validating it per IDNA2008 and DNS length limits
+var domainProfile = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.VerifyDNSLength(true))
+
+type emailConfig struct {
+	displayName, idn, tld bool
+	maxLength             uint
+	blocked               func(domain string) bool
+}
+
+// Email validates RFC 5322 address-spec. By default, display names and non-ASCII addresses are rejected
+func Email(opts ...EmailOpt) ecto.Test[string] {
+	cfg := emailConfig{maxLength: emailMaxLength}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+
+	return ecto.Test[string]{
+		Code:  "strings.Email",
+		Error: "invalid email address",
+		Func:  func(v *string) bool { return cfg.valid(*v) },
+	}
+}
+
+func (cfg *emailConfig) valid(value string) bool {
+	addr, err := mail.ParseAddress(value)
+	if err != nil {
+		return false
+	}
+	// ParseAddress also accepts surrounding whitespace and comments, ex. " john@example.com (John)", and unquotes
+	// local part, ex. `"john doe"@example.com`
+	spec := value
+	if cfg.displayName && strings.HasSuffix(value, ">") {
+		i := strings.LastIndexByte(value, '<')
+		if i < 0 || !plainDisplayName(value[:i]) {
+			return false
+		}
+		spec = value[i+1 : len(value)-1]
+	}
+	if spec != addr.Address && "<"+spec+">" != (&mail.Address{Address: addr.Address}).String() {
+		return false
+	}
+	if !cfg.idn && !isASCII(addr.Address) {
+		return false
+	}
+
+	at := strings.LastIndexByte(addr.Address, '@')
+	local, domain := addr.Address[:at], addr.Address[at+1:]
+	if strings.HasPrefix(domain, "[") {
+		// Domain literals (ex. "user@[192.168.0.1]") aren't accepted by public mail services
+		return false
+	}
+	domain, err = domainProfile.ToASCII(domain)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case len(local) > emailMaxLocalLength:
+		return false
+	case uint(len(local)+1+len(domain)) > cfg.maxLength:
+		return false
+	case cfg.tld && !hasTLD(domain):
+		return false
+	case cfg.blocked != nil && cfg.blocked(strings.ToLower(domain)):
+		return false
+	}
+	return true
+}
+
+// plainDisplayName reports whether display name has neither comments nor folding whitespace
+func plainDisplayName(name string) bool {
+	if strings.HasPrefix(name, " ") || strings.ContainsAny(name, "\r\n\t") {
+		return false
+	}
+	var quoted, escaped bool
+	for _, c := range []byte(name) {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case !quoted && (c == '(' || c == ')'):
+			return false
+		}
+	}
+	return true
+}
+
+func hasTLD(domain string) bool {
+	dot := strings.LastIndexByte(domain, '.')
+	if dot < 0 {
+		return false
+	}
+	tld := domain[dot+1:]
+	return len(tld) >= 2 && strings.Trim(tld, "0123456789") != ""
+}
@@ -0,0 +1,73 @@
+package str
+
+import (
+	"github.com/egsam98/errors"
+	country "github.com/mikekonan/go-countries"
+	"github.com/nyaruka/phonenumbers"
+
+	"github.com/egsam98/ecto"
+)
+
+const errPhoneFormat = ecto.Error("invalid phone number format")
+
+// Phone validates phone number against bundled numbering plans. Numbers in national format are parsed
+// according to defaultCountry (ISO-3166 alpha-2), empty one accepts international format only
+func Phone(defaultCountry string) ecto.Test[string] {
+	region := phoneRegion(defaultCountry)
+	return ecto.Test[string]{
+		Code:   "strings.Phone",
+		Params: map[string]any{"defaultCountry": defaultCountry},
+		Error:  errPhoneFormat,
+		Func: func(v *string) bool {
+			num, err := phonenumbers.Parse(*v, region)
+			return err == nil && phonenumbers.IsValidNumber(num)
+		},
+	}
+}
+
+// PhoneCountry validates phone number to belong to country (ISO-3166 alpha-2).
+// Numbers in national format are parsed according to this country. Unparsable or globally invalid ones fail
+// with format error, valid numbers of another region fail with country error
+func PhoneCountry(code string) ecto.Test[string] {
+	region := phoneRegion(code)
+	errRegion := ecto.Errorf("phone number is not valid for %s", region)
+	return ecto.Test[string]{
+		Code:   "strings.PhoneCountry",
+		Params: map[string]any{"code": code},
+		Error:  errRegion,
+		Func: func(v *string) bool {
+			num, err := phonenumbers.Parse(*v, region)
+			return err == nil && phonenumbers.IsValidNumberForRegion(num, region)
+		},
+		ErrorFunc: func(v *string) ecto.Error {
+			if num, err := phonenumbers.Parse(*v, region); err != nil || !phonenumbers.IsValidNumber(num) {
+				return errPhoneFormat
+			}
+			return errRegion
+		},
+	}
+}
+
+// E164 normalizes phone number into E.164 format (ex. "+14155552671"), to be used with ecto.AtomicSchema.Transform.
+// Numbers in national format are parsed according to defaultCountry (ISO-3166 alpha-2)
+func E164(defaultCountry string) func(string) (string, error) {
+	region := phoneRegion(defaultCountry)
+	return func(v string) (string, error) {
+		num, err := phonenumbers.Parse(v, region)
+		if err != nil || !phonenumbers.IsPossibleNumber(num) {
+			return "", errPhoneFormat
+		}
+		return phonenumbers.Format(num, phonenumbers.E164), nil
+	}
+}
+
+func phoneRegion(code string) string {
+	if code == "" {
+		return ""
+	}
+	c, ok := country.ByAlpha2CodeStr(code)
+	if !ok {
+		panic(errors.Errorf("%q: invalid ISO-3166 country code", code))
+	}
+	return c.Alpha2CodeStr()
+}
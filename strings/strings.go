@@ -3,62 +3,61 @@ package str
 import (
 	"encoding/base64"
 	"net"
-	"net/url"
 	"regexp"
-	"strings"
 	"time"
 	"unicode/utf8"
 
 	iso6391 "github.com/emvi/iso-639-1"
 	country "github.com/mikekonan/go-countries"
-	"github.com/samber/lo"
 	"golang.org/x/text/currency"
 
 	"github.com/egsam98/ecto"
 )
 
-var urlSchemes = lo.Keyify([]string{"http", "https", "ftp", "tcp", "udp", "ws", "wss"})
-
 // Min restricts string length with a lower inclusive bound
 func Min(length uint) ecto.Test[string] {
 	return ecto.Test[string]{
-		Error: ecto.Errorf("must be at least %d characters long", length),
-		Func:  func(v *string) bool { return utf8.RuneCountInString(*v) >= int(length) },
+		Code:   "strings.Min",
+		Params: map[string]any{"length": length},
+		Error:  ecto.Errorf("must be at least %d characters long", length),
+		Func:   func(v *string) bool { return utf8.RuneCountInString(*v) >= int(length) },
 	}
 }
 
 // Max restricts string length with an upper inclusive bound
 func Max(length uint) ecto.Test[string] {
 	return ecto.Test[string]{
-		Error: ecto.Errorf("must be at most %d characters long", length),
-		Func:  func(v *string) bool { return utf8.RuneCountInString(*v) <= int(length) },
+		Code:   "strings.Max",
+		Params: map[string]any{"length": length},
+		Error:  ecto.Errorf("must be at most %d characters long", length),
+		Func:   func(v *string) bool { return utf8.RuneCountInString(*v) <= int(length) },
 	}
 }
 
-// Regex validates string against regular expression
-func Regex(regex *regexp.Regexp) ecto.Test[string] {
+// Len restricts string length to exact value
+func Len(length uint) ecto.Test[string] {
 	return ecto.Test[string]{
-		Error: ecto.Error("must match regex " + regex.String()),
-		Func:  func(v *string) bool { return regex.MatchString(*v) },
+		Code:   "strings.Len",
+		Params: map[string]any{"length": length},
+		Error:  ecto.Errorf("must be exactly %d characters long", length),
+		Func:   func(v *string) bool { return utf8.RuneCountInString(*v) == int(length) },
 	}
 }
 
-func URL() ecto.Test[string] {
+// Regex validates string against regular expression
+func Regex(regex *regexp.Regexp) ecto.Test[string] {
 	return ecto.Test[string]{
-		Error: "invalid URL",
-		Func: func(v *string) bool {
-			if strings.Count(*v, "//") > 1 || strings.Count(*v, "///") > 0 {
-				return false
-			}
-			uri, err := url.Parse(*v)
-			return err == nil && lo.HasKey(urlSchemes, uri.Scheme) && uri.Host != ""
-		},
+		Code:   "strings.Regex",
+		Params: map[string]any{"regex": regex.String()},
+		Error:  ecto.Error("must match regex " + regex.String()),
+		Func:   func(v *string) bool { return regex.MatchString(*v) },
 	}
 }
 
 // Currency ISO-4217 standard for currencies
 func Currency() ecto.Test[string] {
 	return ecto.Test[string]{
+		Code:  "strings.Currency",
 		Error: "invalid ISO-4217 currency",
 		Func: func(v *string) bool {
 			_, err := currency.ParseISO(*v)
@@ -70,6 +69,7 @@ func Currency() ecto.Test[string] {
 // IP address (see net.IP)
 func IP() ecto.Test[string] {
 	return ecto.Test[string]{
+		Code:  "strings.IP",
 		Error: "invalid IP address",
 		Func:  func(v *string) bool { return net.ParseIP(*v) != nil },
 	}
@@ -78,6 +78,7 @@ func IP() ecto.Test[string] {
 // Lang ISO-639-1 standard for languages
 func Lang() ecto.Test[string] {
 	return ecto.Test[string]{
+		Code:  "strings.Lang",
 		Error: "invalid ISO-639-1 language code",
 		Func:  func(v *string) bool { return iso6391.ValidCode(*v) },
 	}
@@ -86,6 +87,7 @@ func Lang() ecto.Test[string] {
 // Country ISO-3166 standard for countries
 func Country() ecto.Test[string] {
 	return ecto.Test[string]{
+		Code:  "strings.Country",
 		Error: "invalid ISO-3166 country code",
 		Func: func(v *string) bool {
 			_, ok := country.ByAlpha2CodeStr(*v)
@@ -96,6 +98,7 @@ func Country() ecto.Test[string] {
 
 func Base64() ecto.Test[string] {
 	return ecto.Test[string]{
+		Code:  "strings.Base64",
 		Error: "invalid base64",
 		Func: func(v *string) bool {
 			_, err := base64.StdEncoding.DecodeString(*v)
@@ -106,7 +109,9 @@ func Base64() ecto.Test[string] {
 
 func DateTime(layout string) ecto.Test[string] {
 	return ecto.Test[string]{
-		Error: ecto.Error("datetime format must be " + layout),
+		Code:   "strings.DateTime",
+		Params: map[string]any{"layout": layout},
+		Error:  ecto.Error("datetime format must be " + layout),
 		Func: func(v *string) bool {
 			_, err := time.Parse(layout, *v)
 			return err == nil
@@ -0,0 +1,37 @@
+package str
+
+import (
+	"strconv"
+
+	"github.com/egsam98/errors"
+
+	"github.com/egsam98/ecto"
+)
+
+// Tags for ecto.FromTags
+func init() {
+	ecto.RegisterTag("min", lengthTag(Min))
+	ecto.RegisterTag("max", lengthTag(Max))
+	ecto.RegisterTag("len", lengthTag(Len))
+	ecto.RegisterTag("url", ecto.NoParamTag(URL))
+	ecto.RegisterTag("email", ecto.NoParamTag(func() ecto.Test[string] { return Email() }))
+	ecto.RegisterTag("phone", func(country string) (ecto.Test[string], error) { return Phone(country), nil })
+	ecto.RegisterTag("ip", ecto.NoParamTag(IP))
+	ecto.RegisterTag("base64", ecto.NoParamTag(Base64))
+	ecto.RegisterTag("currency", ecto.NoParamTag(Currency))
+	ecto.RegisterTag("lang", ecto.NoParamTag(Lang))
+	ecto.RegisterTag("country", ecto.NoParamTag(Country))
+	ecto.RegisterTag("ascii", ecto.NoParamTag(ASCII))
+	ecto.RegisterTag("printable", ecto.NoParamTag(Printable))
+	ecto.RegisterTag("trimmed", ecto.NoParamTag(Trimmed))
+}
+
+func lengthTag(test func(length uint) ecto.Test[string]) ecto.TagFunc[string] {
+	return func(param string) (ecto.Test[string], error) {
+		length, err := strconv.ParseUint(param, 10, 0)
+		if err != nil {
+			return ecto.Test[string]{}, errors.Wrap(err, "parse length")
+		}
+		return test(uint(length)), nil
+	}
+}
@@ -0,0 +1,141 @@
+package str
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/egsam98/errors"
+	"github.com/rivo/uniseg"
+
+	"github.com/egsam98/ecto"
+)
+
+// MinGraphemes restricts string length in user-perceived characters (grapheme clusters, ex. emoji with modifiers
+// or letters with combining marks) with a lower inclusive bound
+func MinGraphemes(length uint) ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:   "strings.MinGraphemes",
+		Params: map[string]any{"length": length},
+		Error:  ecto.Errorf("must be at least %d characters long", length),
+		Func:   func(v *string) bool { return uniseg.GraphemeClusterCount(*v) >= int(length) },
+	}
+}
+
+// MaxGraphemes restricts string length in user-perceived characters (grapheme clusters, ex. emoji with modifiers
+// or letters with combining marks) with an upper inclusive bound
+func MaxGraphemes(length uint) ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:   "strings.MaxGraphemes",
+		Params: map[string]any{"length": length},
+		Error:  ecto.Errorf("must be at most %d characters long", length),
+		Func:   func(v *string) bool { return uniseg.GraphemeClusterCount(*v) <= int(length) },
+	}
+}
+
+// MinBytes restricts UTF-8 encoded string length with a lower inclusive bound
+func MinBytes(length uint) ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:   "strings.MinBytes",
+		Params: map[string]any{"length": length},
+		Error:  ecto.Errorf("must be at least %d bytes long", length),
+		Func:   func(v *string) bool { return len(*v) >= int(length) },
+	}
+}
+
+// MaxBytes restricts UTF-8 encoded string length with an upper inclusive bound (ex. database column limits)
+func MaxBytes(length uint) ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:   "strings.MaxBytes",
+		Params: map[string]any{"length": length},
+		Error:  ecto.Errorf("must be at most %d bytes long", length),
+		Func:   func(v *string) bool { return len(*v) <= int(length) },
+	}
+}
+
+// Runes restricts characters to Unicode scripts (see unicode.Scripts, ex. "Latin", "Cyrillic")
+// or categories (see unicode.Categories, ex. "Nd", "Zs", "P"). Characters shared by scripts, i.e. ones of
+// Common (spaces, digits, punctuation) and Inherited (combining marks) scripts, are allowed if any script is
+// listed. Panics if name is unknown
+func Runes(names ...string) ecto.Test[string] {
+	tables := make([]*unicode.RangeTable, len(names))
+	var scripts bool
+	for i, name := range names {
+		table, ok := unicode.Scripts[name]
+		scripts = scripts || ok
+		if !ok {
+			table, ok = unicode.Categories[name]
+		}
+		if !ok {
+			panic(errors.Errorf("%q is neither Unicode script nor category", name))
+		}
+		tables[i] = table
+	}
+	if scripts {
+		tables = append(tables, unicode.Common, unicode.Inherited)
+	}
+
+	return ecto.Test[string]{
+		Code:   "strings.Runes",
+		Params: map[string]any{"names": names},
+		Error:  ecto.Error("must contain only " + strings.Join(names, ", ") + " characters"),
+		Func: func(v *string) bool {
+			return utf8.ValidString(*v) && strings.IndexFunc(*v, func(r rune) bool {
+				return !unicode.IsOneOf(tables, r)
+			}) < 0
+		},
+	}
+}
+
+// NoControl forbids control characters (ex. "\x00", "\n", "\u009b") and invisible format ones (ex. bidi override
+// "\u202e", zero width space "\u200b") except for zero width joiners composing emoji and ligatures
+func NoControl() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "strings.NoControl",
+		Error: "must not contain control characters",
+		Func:  func(v *string) bool { return strings.IndexFunc(*v, isControl) < 0 },
+	}
+}
+
+func isControl(r rune) bool {
+	return unicode.IsControl(r) || (unicode.Is(unicode.Cf, r) && r != '\u200c' && r != '\u200d')
+}
+
+// Trimmed forbids leading and trailing whitespace
+func Trimmed() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "strings.Trimmed",
+		Error: "must not have leading or trailing whitespace",
+		Func:  func(v *string) bool { return strings.TrimSpace(*v) == *v },
+	}
+}
+
+// Printable restricts characters to printable ones (see unicode.IsPrint), i.e. ASCII space is the only
+// whitespace allowed
+func Printable() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "strings.Printable",
+		Error: "must contain only printable characters",
+		Func: func(v *string) bool {
+			return utf8.ValidString(*v) && strings.IndexFunc(*v, func(r rune) bool { return !unicode.IsPrint(r) }) < 0
+		},
+	}
+}
+
+// ASCII restricts characters to ASCII ones
+func ASCII() ecto.Test[string] {
+	return ecto.Test[string]{
+		Code:  "strings.ASCII",
+		Error: "must contain only ASCII characters",
+		Func:  func(v *string) bool { return isASCII(*v) },
+	}
+}
+
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
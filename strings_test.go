@@ -0,0 +1,178 @@
+package ecto_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/egsam98/ecto"
+	ectos "github.com/egsam98/ecto/strings"
+)
+
+func TestEmail(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		opts  []ectos.EmailOpt
+		valid bool
+	}{
+		{value: "john@example.com", valid: true},
+		{value: "john.doe+tag@mail.example.org", valid: true},
+		{value: `"john doe"@example.com`, valid: true},
+		{value: "john@localhost", valid: true},
+		{value: "john@localhost", opts: []ectos.EmailOpt{ectos.EmailRequireTLD()}},
+		{value: "john@example.com", opts: []ectos.EmailOpt{ectos.EmailRequireTLD()}, valid: true},
+		{value: "john"},
+		{value: "john@"},
+		{value: "@example.com"},
+		{value: "john@@example.com"},
+		{value: "john@[192.168.0.1]"},
+		{value: "john@exa_mple.com"},
+		// Display names
+		{value: "John <john@example.com>"},
+		{value: "<john@example.com>"},
+		{value: "John <john@example.com>", opts: []ectos.EmailOpt{ectos.EmailAllowDisplayName()}, valid: true},
+		// Whitespace and comments
+		{value: " john@example.com "},
+		{value: "john@example.com\n"},
+		{value: "john@example.com (John)"},
+		{value: "(John) john@example.com"},
+		{value: "john@example.com (John)", opts: []ectos.EmailOpt{ectos.EmailAllowDisplayName()}},
+		{value: "John (Work) <john@example.com>", opts: []ectos.EmailOpt{ectos.EmailAllowDisplayName()}},
+		{value: "John <john@example.com> (Work)", opts: []ectos.EmailOpt{ectos.EmailAllowDisplayName()}},
+		{value: " John <john@example.com>", opts: []ectos.EmailOpt{ectos.EmailAllowDisplayName()}},
+		{value: "John\r\n Doe <john@example.com>", opts: []ectos.EmailOpt{ectos.EmailAllowDisplayName()}},
+		{value: `"John (Work)" <john@example.com>`, opts: []ectos.EmailOpt{ectos.EmailAllowDisplayName()}, valid: true},
+		{value: "john@example.com", opts: []ectos.EmailOpt{ectos.EmailAllowDisplayName()}, valid: true},
+		// IDN
+		{value: "user@пример.рф"},
+		{value: "user@пример.рф", opts: []ectos.EmailOpt{ectos.EmailAllowIDN()}, valid: true},
+		{value: "почта@пример.рф", opts: []ectos.EmailOpt{ectos.EmailAllowIDN()}, valid: true},
+		{value: "user@xn--e1afmkfd.xn--p1ai", valid: true},
+		{value: "user@-пример.рф", opts: []ectos.EmailOpt{ectos.EmailAllowIDN()}},
+		// Lengths and blocklist
+		{value: strings.Repeat("a", 64) + "@example.com", valid: true},
+		{value: strings.Repeat("a", 65) + "@example.com"},
+		{value: strings.Repeat("п", 32) + "@example.com", opts: []ectos.EmailOpt{ectos.EmailAllowIDN()}, valid: true},
+		{value: strings.Repeat("п", 33) + "@example.com", opts: []ectos.EmailOpt{ectos.EmailAllowIDN()}}, // 66 octets
+		{value: "john@example.com", opts: []ectos.EmailOpt{ectos.EmailMaxLength(10)}},
+		{
+			value: "john@Mailinator.com",
+			opts:  []ectos.EmailOpt{ectos.EmailBlocklist(func(domain string) bool { return domain == "mailinator.com" })},
+		},
+	} {
+		test := ectos.Email(tc.opts...)
+		assert.Equal(t, tc.valid, test.Run(&tc.value) == nil, tc.value)
+	}
+}
+
+func TestUnicode(t *testing.T) {
+	const (
+		combining = "e\u0301"                                    // é of 2 runes
+		family    = "\U0001F468\u200D\U0001F469\u200D\U0001F467" // 👨‍👩‍👧 of 5 runes
+		thumbsUp  = "\U0001F44D\U0001F3FD"                       // 👍🏽 with skin tone modifier
+		flag      = "\U0001F1FA\U0001F1F8"                       // 🇺🇸 of regional indicators
+	)
+
+	for _, tc := range []struct {
+		test  ecto.Test[string]
+		value string
+		valid bool
+	}{
+		{test: ectos.MinGraphemes(1), value: ""},
+		{test: ectos.MinGraphemes(1), value: combining, valid: true},
+		{test: ectos.MinGraphemes(2), value: combining},
+		{test: ectos.MinGraphemes(2), value: family},
+		{test: ectos.MinGraphemes(2), value: family + combining, valid: true},
+		{test: ectos.MinGraphemes(3), value: "abc", valid: true},
+		{test: ectos.MaxGraphemes(1), value: combining, valid: true},
+		{test: ectos.MaxGraphemes(1), value: family, valid: true},
+		{test: ectos.MaxGraphemes(1), value: thumbsUp, valid: true},
+		{test: ectos.MaxGraphemes(1), value: flag, valid: true},
+		{test: ectos.MaxGraphemes(1), value: flag + flag},
+		{test: ectos.MaxGraphemes(4), value: "caf" + combining, valid: true},
+		{test: ectos.MaxGraphemes(4), value: "caf" + combining + combining},
+		{test: ectos.MaxGraphemes(0), value: "", valid: true},
+
+		{test: ectos.Runes("Latin"), value: "Straße", valid: true},
+		{test: ectos.Runes("Latin"), value: "Hello world, 2024!", valid: true}, // Common script
+		{test: ectos.Runes("Latin"), value: "Cafe\u0301", valid: true},         // Inherited combining mark
+		{test: ectos.Runes("Latin"), value: "Hello мир"},
+		{test: ectos.Runes("Latin", "Zs"), value: "Hello world", valid: true},
+		{test: ectos.Runes("Cyrillic"), value: "Привет", valid: true},
+		{test: ectos.Runes("Cyrillic"), value: "Пpивет"}, // Latin p
+		{test: ectos.Runes("Han", "Hiragana"), value: "日本語のテキスト"},
+		{test: ectos.Runes("Han", "Hiragana", "Katakana"), value: "日本語のテキスト", valid: true},
+		{test: ectos.Runes("Nd"), value: "0123", valid: true},
+		{test: ectos.Runes("Nd"), value: "٠١٢٣", valid: true}, // Arabic-Indic digits
+		{test: ectos.Runes("Nd"), value: "½"},
+		{test: ectos.Runes("L"), value: "Aбγ", valid: true},
+		{test: ectos.Runes("L", "M"), value: combining, valid: true},
+		{test: ectos.Runes("L"), value: combining},
+		{test: ectos.Runes("L", "P"), value: "a-b!", valid: true},
+		{test: ectos.Runes("Latin"), value: "a\xff"},
+		{test: ectos.Runes("Latin"), value: "", valid: true},
+
+		{test: ectos.NoControl(), value: "Hello, world! " + family, valid: true},
+		{test: ectos.NoControl(), value: "a\u200Bb"}, // Zero width space is a format character
+		{test: ectos.NoControl(), value: "abc\u202Etxt.exe"},
+		{test: ectos.NoControl(), value: "\uFEFFabc"},
+		{test: ectos.NoControl(), value: "می\u200Cخواهم", valid: true}, // Zero width non-joiner of Persian
+		{test: ectos.NoControl(), value: "a\nb"},
+		{test: ectos.NoControl(), value: "a\tb"},
+		{test: ectos.NoControl(), value: "a\x00"},
+		{test: ectos.NoControl(), value: "\u009b"},
+
+		{test: ectos.Trimmed(), value: "a b", valid: true},
+		{test: ectos.Trimmed(), value: "", valid: true},
+		{test: ectos.Trimmed(), value: " a"},
+		{test: ectos.Trimmed(), value: "a\n"},
+		{test: ectos.Trimmed(), value: "\u00A0a"},
+		{test: ectos.Trimmed(), value: "a\u3000"},
+
+		{test: ectos.Printable(), value: "Hello, world! " + combining + thumbsUp, valid: true},
+		{test: ectos.Printable(), value: "日本語", valid: true},
+		{test: ectos.Printable(), value: "a\tb"},
+		{test: ectos.Printable(), value: "a\u00A0b"},
+		{test: ectos.Printable(), value: "a\u200Bb"},
+		{test: ectos.Printable(), value: "\xff"},
+
+		{test: ectos.ASCII(), value: "Hello, world!~", valid: true},
+		{test: ectos.ASCII(), value: "\x00\x7f", valid: true},
+		{test: ectos.ASCII(), value: "", valid: true},
+		{test: ectos.ASCII(), value: "caf" + combining},
+		{test: ectos.ASCII(), value: "\xff"},
+	} {
+		assert.Equal(t, tc.valid, tc.test.Run(&tc.value) == nil, "%s %q", tc.test.Code, tc.value)
+	}
+
+	assert.Panics(t, func() { ectos.Runes("Klingon") })
+}
+
+func TestPhone(t *testing.T) {
+	for _, tc := range []struct {
+		test  ecto.Test[string]
+		value string
+		err   ecto.Error
+	}{
+		{test: ectos.Phone("US"), value: "(415) 555-2671"},
+		{test: ectos.Phone(""), value: "+44 20 7946 0958"},
+		{test: ectos.Phone(""), value: "020 7946 0958", err: "invalid phone number format"},
+		{test: ectos.Phone("US"), value: "+1 123", err: "invalid phone number format"},
+		{test: ectos.PhoneCountry("us"), value: "(415) 555-2671"},
+		{test: ectos.PhoneCountry("US"), value: "+1 415 555 2671"},
+		{test: ectos.PhoneCountry("GB"), value: "020 7946 0958"},
+		{test: ectos.PhoneCountry("US"), value: "+44 20 7946 0958", err: "phone number is not valid for US"},
+		{test: ectos.PhoneCountry("US"), value: "+1 123", err: "invalid phone number format"},
+		{test: ectos.PhoneCountry("US"), value: "phone", err: "invalid phone number format"},
+		{test: ectos.PhoneCountry("US"), value: "", err: "invalid phone number format"},
+	} {
+		if err := tc.test.Run(&tc.value); tc.err == "" {
+			assert.Nil(t, err, tc.value)
+		} else if assert.NotNil(t, err, tc.value) {
+			assert.Equal(t, tc.err, *err, tc.value)
+		}
+	}
+
+	assert.Panics(t, func() { ectos.PhoneCountry("XX") })
+}
@@ -2,6 +2,7 @@ package ecto
 
 import (
 	"cmp"
+	"context"
 	"encoding/json"
 	"reflect"
 	"strings"
@@ -12,11 +13,15 @@ import (
 
 var _ Schema = (*StructSchema[any])(nil)
 var _ IStructSchema = (*StructSchema[any])(nil)
+var _ TypedSchema[any] = (*StructSchema[any])(nil)
 
 // StructSchema represents schema for struct types via hashmap as a struct field to its schema
 type StructSchema[T any] struct {
-	fields M
-	meta   map[string]FieldMeta
+	fields   M
+	meta     map[string]FieldMeta
+	fieldPtr func(ptr *T, key string) any
+	// parent reports whether tests of fields need Parent
+	parent bool
 }
 
 type FieldMeta struct {
@@ -38,7 +43,12 @@ func Struct[T any](fields M) StructSchema[T] {
 }
 
 // Process may return MapError
-func (s StructSchema[T]) Process(ptr *T) error { return s.process(ptr) }
+func (s StructSchema[T]) Process(ptr *T) error { return s.process(context.Background(), ptr) }
+
+// ProcessContext is Process with context passed to field schemas
+func (s StructSchema[T]) ProcessContext(ctx context.Context, ptr *T) error {
+	return s.process(ctx, ptr)
+}
 
 // Cast deserializes bytes into type and runs Process.
 func (s StructSchema[T]) Cast(src []byte, deserialize func([]byte, any) error, opts ...CastOpt) (T, error) {
@@ -47,17 +57,12 @@ func (s StructSchema[T]) Cast(src []byte, deserialize func([]byte, any) error, o
 		return data, errors.Wrapf(err, "deserialize %s into %T", src, data)
 	}
 
-	var cfg castConfig
-	for _, opt := range opts {
-		if opt != nil {
-			opt(&cfg)
-		}
-	}
+	cfg := newCastConfig(opts)
 	if cfg.scrub {
 		ScrubAny(&data)
 	}
 
-	return data, s.Process(&data)
+	return data, s.process(cmp.Or(cfg.ctx, context.Background()), &data)
 }
 
 func (s StructSchema[T]) CastJSON(src []byte, opts ...CastOpt) (T, error) {
@@ -70,34 +75,37 @@ func (s StructSchema[T]) CastToAny(src []byte, deserialize func([]byte, any) err
 
 // Extend existing schema
 func (s StructSchema[T]) Extend(fields M) StructSchema[T] {
-	return Struct[T](lo.Assign(s.fields, fields))
+	extended := Struct[T](lo.Assign(s.fields, fields))
+	extended.fieldPtr = s.fieldPtr
+	return extended
 }
 
-func (s StructSchema[T]) ForType() reflect.Type { return reflect.TypeFor[T]() }
+// FieldPtrFunc replaces reflection-based access to struct fields with fn returning pointer to field by its name
+// or nil if field is unknown, ex. generated by cmd/ectogen
+func (s StructSchema[T]) FieldPtrFunc(fn func(ptr *T, key string) any) StructSchema[T] {
+	s.fieldPtr = fn
+	return s
+}
 
-func (s StructSchema[T]) process(ptrStruct any) error {
-	if len(s.fields) == 0 {
-		return nil
-	}
+func (s StructSchema[T]) ForType() reflect.Type { return reflect.TypeFor[T]() }
 
-	rv := reflect.ValueOf(ptrStruct).Elem()
-	var errs MapError
-	for key, schema := range s.fields {
-		keyMeta, ok := s.meta[key]
-		if !ok {
-			s.panicMissingKey(key)
-		}
+func (StructSchema[T]) typed(T) {}
 
-		ptr := rv.Field(keyMeta.Index).Addr().Interface()
-		if err := schema.process(ptr); err != nil {
-			errs.Add(keyMeta.Tag, err)
-		}
+func (s StructSchema[T]) describe(d *describer) Node {
+	return Node{
+		Kind:   KindStruct,
+		Type:   reflect.TypeFor[T]().String(),
+		Fields: describeFields(d, s.fields, s.meta),
 	}
+}
 
-	if len(errs) > 0 {
-		return errs
+func (s StructSchema[T]) process(ctx context.Context, ptrStruct any) error {
+	var fieldPtr func(key string) any
+	if s.fieldPtr != nil {
+		ptr := ptrStruct.(*T)
+		fieldPtr = func(key string) any { return s.fieldPtr(ptr, key) }
 	}
-	return nil
+	return processFields(ctx, ptrStruct, s.fields, s.meta, s.parent, fieldPtr, s.panicMissingKey)
 }
 
 func (s StructSchema[T]) Fields() M { return s.fields }
@@ -117,14 +125,8 @@ func (s *StructSchema[T]) makeMeta() {
 	}
 
 	typ := reflect.TypeFor[T]()
-	for i := range typ.NumField() {
-		field := typ.Field(i)
-		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
-		s.meta[field.Name] = FieldMeta{
-			Index: i,
-			Tag:   cmp.Or(tag, field.Name),
-		}
-	}
+	s.meta = fieldsMeta(typ)
+	s.parent = false
 	for key, schema := range s.fields {
 		field, ok := typ.FieldByName(key)
 		if !ok {
@@ -133,6 +135,11 @@ func (s *StructSchema[T]) makeMeta() {
 		if err := validateSchema(field.Type, schema); err != nil {
 			panic(errors.Wrapf(err, "%T: %s", s, key))
 		}
+		parent, err := checkParent(typ, schema)
+		if err != nil {
+			panic(errors.Wrapf(err, "%T: %s", s, key))
+		}
+		s.parent = s.parent || parent
 	}
 }
 
@@ -140,6 +147,83 @@ func (s StructSchema[T]) panicMissingKey(key string) {
 	panic(errors.Errorf("%T: Missing struct schema key: %s", s, key))
 }
 
+// checkParent validates struct type by tests of field schema (see Test.CheckParent) not crossing nested structs.
+// Reports whether any of tests needs Parent
+func checkParent(typ reflect.Type, schema Schema) (bool, error) {
+	var parent bool
+	var err error
+	node := schema.describe(&describer{visiting: make(map[any]bool), unresolved: true})
+	node.Walk(func(_ []string, node *Node) bool {
+		for _, test := range node.Tests {
+			parent = parent || test.contextual
+			if test.checkParent != nil && err == nil {
+				err = test.checkParent(typ)
+			}
+		}
+		return node.Kind != KindStruct
+	})
+	return parent, err
+}
+
+// processFields runs schemas of struct fields collecting their errors by FieldMeta.Tag.
+// Parent is passed to fields if parent is true. Fields are accessed via reflection if fieldPtr is nil
+func processFields(
+	ctx context.Context,
+	ptrStruct any,
+	fields M,
+	meta map[string]FieldMeta,
+	parent bool,
+	fieldPtr func(key string) any,
+	missingKey func(string),
+) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	if parent {
+		ctx = context.WithValue(ctx, parentKey{}, ptrStruct)
+	}
+	if fieldPtr == nil {
+		rv := reflect.ValueOf(ptrStruct).Elem()
+		fieldPtr = func(key string) any { return rv.Field(meta[key].Index).Addr().Interface() }
+	}
+
+	var errs MapError
+	for key, schema := range fields {
+		keyMeta, ok := meta[key]
+		if !ok {
+			missingKey(key)
+		}
+
+		ptr := fieldPtr(key)
+		if ptr == nil {
+			missingKey(key)
+		}
+		if err := schema.process(ctx, ptr); err != nil {
+			errs.Add(keyMeta.Tag, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// fieldsMeta maps struct field names to their indexes and json tags
+func fieldsMeta(typ reflect.Type) map[string]FieldMeta {
+	meta := make(map[string]FieldMeta, typ.NumField())
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		meta[field.Name] = FieldMeta{
+			Index: i,
+			Tag:   cmp.Or(tag, field.Name),
+		}
+	}
+	return meta
+}
+
 type CastOpt func(*castConfig)
 
 // Scrub replaces empty strings with nil for every `*string` type
@@ -147,6 +231,28 @@ func Scrub() CastOpt {
 	return func(cfg *castConfig) { cfg.scrub = true }
 }
 
+// Context passes ctx to schemas processing deserialized data
+func Context(ctx context.Context) CastOpt {
+	return func(cfg *castConfig) { cfg.ctx = ctx }
+}
+
+// Filename sets name of source reported by SourceError of CastYAML and CastTOML
+func Filename(name string) CastOpt {
+	return func(cfg *castConfig) { cfg.filename = name }
+}
+
 type castConfig struct {
-	scrub bool
+	scrub    bool
+	ctx      context.Context
+	filename string
+}
+
+func newCastConfig(opts []CastOpt) castConfig {
+	var cfg castConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return cfg
 }
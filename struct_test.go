@@ -1,6 +1,7 @@
 package ecto_test
 
 import (
+	"context"
 	"regexp"
 	"testing"
 
@@ -10,6 +11,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/egsam98/ecto"
+	integer "github.com/egsam98/ecto/ints"
+	"github.com/egsam98/ecto/password"
 	ectosl "github.com/egsam98/ecto/slices"
 	ectos "github.com/egsam98/ecto/strings"
 )
@@ -35,6 +38,8 @@ var structSchema = ecto.Struct[Data](ecto.M{
 
 type Hello string
 
+//go:generate go run ./cmd/ectogen -type Data,C,F -fast
+
 type Data struct {
 	A Hello
 	B string
@@ -82,8 +87,161 @@ func TestStructSchema_Process(t *testing.T) {
 	})
 }
 
+func TestStructSchema_DefaultFunc(t *testing.T) {
+	type Entity struct {
+		ID    uuid.UUID
+		Owner *uuid.UUID
+	}
+	schema := ecto.Struct[Entity](ecto.M{
+		"ID":    ecto.Atomic[uuid.UUID]().DefaultFunc(uuid.New),
+		"Owner": ecto.Ptr[uuid.UUID](ecto.Atomic[uuid.UUID]()).DefaultFunc(uuid.New),
+	})
+
+	var first, second Entity
+	require.NoError(t, schema.Process(&first))
+	require.NoError(t, schema.Process(&second))
+	assert.NotEqual(t, uuid.Nil, first.ID)
+	assert.NotEqual(t, first.ID, second.ID)
+	require.NotNil(t, first.Owner)
+	assert.NotEqual(t, *first.Owner, *second.Owner)
+
+	id := uuid.New()
+	given := Entity{ID: id}
+	require.NoError(t, schema.Process(&given))
+	assert.Equal(t, id, given.ID)
+}
+
 func BenchmarkEcto(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		require.NoError(b, structSchema.Process(&data))
 	}
 }
+
+func TestGeneratedSchema(t *testing.T) {
+	schema := DataSchema().
+		B(ecto.String().Required()).
+		C(CSchema().C1(ecto.String().Required()).Build()).
+		F(ecto.Slice[[]F](FSchema().F1(ecto.String().Required()).Build())).
+		G(ecto.Ptr[int](ecto.Int()).Required()).
+		Build()
+	assert.NoError(t, schema.Process(&data))
+
+	invalid := Data{F: []F{{}}}
+	assert.EqualError(t, schema.Process(&invalid), `{"B":["required"],"C":{"C1":["required"]},"G":["required"],"f":{"0":{"f1":["required"]}}}`)
+	assert.Equal(t, ecto.Struct[Data](schema.Fields()).Process(&invalid), schema.Process(&invalid))
+	assert.Nil(t, DataFieldPtr(&invalid, "unknown"))
+}
+
+func TestParent(t *testing.T) {
+	type SignUp struct {
+		Email    string
+		Password string
+	}
+	schema := ecto.Struct[SignUp](ecto.M{
+		"Password": ecto.String().Test(password.Policy{
+			MinLength:   8,
+			MinSequence: 4,
+			Fields:      []string{"Email"},
+		}.Tests()...),
+	})
+
+	assert.NoError(t, schema.Process(&SignUp{Email: "john@example.com", Password: "correct horse"}))
+	assert.EqualError(t, schema.Process(&SignUp{Email: "john@example.com", Password: "John1234"}),
+		`{"Password":["password must not contain sequences of 4 characters like 1234 or qwerty",`+
+			`"password must not contain Email"]}`)
+	assert.Nil(t, ecto.Parent(context.Background()))
+
+	// Parent is passed only if some test needs it
+	var parent any
+	schema = ecto.Struct[SignUp](ecto.M{"Email": ecto.String().DefaultContext(func(ctx context.Context) (string, error) {
+		parent = ecto.Parent(ctx)
+		return "", nil
+	})})
+	require.NoError(t, schema.Process(&SignUp{}))
+	assert.Nil(t, parent)
+
+	schema = schema.Extend(ecto.M{"Password": ecto.String().Test(password.NotContainingFields("Email"))})
+	require.NoError(t, schema.Process(&SignUp{}))
+	assert.NotNil(t, parent)
+}
+
+type Config struct {
+	Port    int              `json:"port" yaml:"port" toml:"port"`
+	Servers []ConfigServer   `json:"servers" yaml:"servers" toml:"servers"`
+	DB      *ConfigDB        `json:"db" yaml:"database" toml:"database"`
+	Labels  map[string]int64 `json:"labels"`
+}
+
+type ConfigServer struct {
+	Host string `json:"host" yaml:"host" toml:"host"`
+}
+
+type ConfigDB struct {
+	User string `json:"user" yaml:"user_name" toml:"user_name"`
+	Name string
+}
+
+var configSchema = ecto.Struct[Config](ecto.M{
+	"Port": ecto.Int().Test(integer.Max(65535)),
+	"Servers": ecto.Slice[[]ConfigServer](ecto.Struct[ConfigServer](ecto.M{
+		"Host": ecto.String().Required().Test(ectos.Min(3)),
+	})),
+	"DB": ecto.Ptr[ConfigDB](ecto.Struct[ConfigDB](ecto.M{
+		"User": ecto.String().Required(),
+		"Name": ecto.String().Test(ectos.Max(3)),
+	})).Required(),
+})
+
+func TestStructSchema_CastYAML(t *testing.T) {
+	src := `port: 70000
+servers:
+  - host: localhost
+  - host: a
+database:
+  name: "main"
+`
+	_, err := configSchema.CastYAML([]byte(src), ecto.Filename("config.yaml"))
+	assert.EqualError(t, err, `config.yaml:1:7: port: must be 65535 maximum
+config.yaml:4:11: servers.1.host: must be at least 3 characters long
+config.yaml:6:3: database.user_name: required
+config.yaml:6:9: database.name: must be at most 3 characters long`)
+
+	var srcErr *ecto.SourceError
+	require.ErrorAs(t, err, &srcErr)
+	assert.Equal(t, ecto.SourceEntry{Line: 1, Column: 7, Path: []string{"port"}, Error: "must be 65535 maximum"}, srcErr.Entries[0])
+	assert.Equal(t, ecto.ListError{"required"}, srcErr.Err["database"].(ecto.MapError)["user_name"])
+
+	_, err = configSchema.CastYAML(nil)
+	assert.EqualError(t, err, "1:1: database: required")
+
+	cfg, err := configSchema.CastYAML([]byte("database: {user_name: root}\nport: 80"))
+	require.NoError(t, err)
+	assert.Equal(t, Config{Port: 80, DB: &ConfigDB{User: "root"}}, cfg)
+
+	_, err = configSchema.CastYAML([]byte("port: [1"))
+	assert.Error(t, err)
+	assert.NotErrorAs(t, err, &srcErr)
+}
+
+func TestStructSchema_CastTOML(t *testing.T) {
+	src := `port = 70000
+
+[[servers]]
+host = "localhost"
+
+[[servers]]
+host = "a"
+
+[Database]
+name = "main"
+`
+	_, err := configSchema.CastTOML([]byte(src))
+	assert.EqualError(t, err, `1:8: port: must be 65535 maximum
+7:8: servers.1.host: must be at least 3 characters long
+9:2: database.user_name: required
+10:8: database.Name: must be at most 3 characters long`)
+
+	_, err = configSchema.CastTOML([]byte(`servers = [{host = "a"}]
+database.user_name = "root"`), ecto.Filename("config.toml"))
+	assert.EqualError(t, err, "config.toml:1:20: servers.0.host: must be at least 3 characters long")
+}
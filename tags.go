@@ -0,0 +1,672 @@
+package ecto
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/egsam98/errors"
+	"github.com/samber/lo"
+)
+
+var _ IAtomicOrPtrSchema = (*tagAtomicSchema)(nil)
+var _ IAtomicOrPtrSchema = (*tagPtrSchema)(nil)
+var _ ISliceSchema = (*tagSliceSchema)(nil)
+var _ IStructSchema = (*tagStructSchema)(nil)
+
+// TagFunc constructs a Test from parameter of struct tag, ex. "3" for `ecto:"min=3"` or "" for `ecto:"url"`
+type TagFunc[T any] func(param string) (Test[T], error)
+
+// NoParamTag adapts constructor of a Test without parameters to TagFunc, the parameter must be empty
+func NoParamTag[T any](test func() Test[T]) TagFunc[T] {
+	return func(param string) (Test[T], error) {
+		if param != "" {
+			return Test[T]{}, errors.Errorf("unexpected parameter %q", param)
+		}
+		return test(), nil
+	}
+}
+
+// RegisterTag makes tag name usable in FromTags for fields of type T. Integer, float and string fields
+// fall back to tags registered for int64, uint64, float64 and string respectively, so one registration
+// covers all types of the kind (ex. int32, type definitions of string). Registering the same name for
+// the same type replaces the previous one.
+// Subpackages register their tests on import (ex. "url", "email" of ecto/strings, "ipv4", "hostname" of ecto/network)
+func RegisterTag[T any](name string, fn TagFunc[T]) {
+	validateTagName(name)
+	tagRegistry.Lock()
+	defer tagRegistry.Unlock()
+	addTagEntry(tagRegistry.tags, name, fn)
+}
+
+// TagOpt configures FromTags and ParseTags
+type TagOpt func(*tagConfig)
+
+// TagKey sets key of struct tags to read ("ecto" by default)
+func TagKey(key string) TagOpt {
+	return func(cfg *tagConfig) { cfg.key = key }
+}
+
+// TagAlias makes alias to be treated as tag name (ex. "gte" as "min")
+func TagAlias(alias, name string) TagOpt {
+	return func(cfg *tagConfig) { cfg.aliases[alias] = name }
+}
+
+// WithTag is RegisterTag visible to this FromTags or ParseTags call only. It takes precedence over registered tags
+func WithTag[T any](name string, fn TagFunc[T]) TagOpt {
+	validateTagName(name)
+	return func(cfg *tagConfig) { addTagEntry(cfg.tags, name, fn) }
+}
+
+type tagConfig struct {
+	key     string
+	aliases map[string]string
+	tags    map[string][]tagEntry
+}
+
+// FromTags builds StructSchema from `ecto` struct tags, ex. `ecto:"required,min=3,max=50,oneof=a b,url"`.
+// Built-in tags:
+//   - required: value must not be Go zero-value (nil for pointers and slices)
+//   - required_if=Field1 value1 Field2 value2: required if all sibling fields have values (string representation)
+//   - omitempty: skip other tags for Go zero-value
+//   - min, max: bounds of numbers, length of slices
+//   - len: exact length of slices
+//   - oneof: space-separated variants of numbers or strings
+//   - dive: the following tags are applied to slice elements instead of slice itself
+//
+// Tags of pointers except for required ones are applied to the pointee. Struct fields, pointers to them and
+// slices of them are processed recursively if their types have tagged fields, so recursive types are supported.
+// Use "-" to skip a field. Tags of strings (min, max, len, url etc.) are registered by ecto/strings package,
+// custom tags are added via RegisterTag. Hand-written field schemas may be
+// added or replace generated ones via Extend. Panics if any tag is unknown or malformed (see ParseTags)
+func FromTags[T any](opts ...TagOpt) StructSchema[T] {
+	schema, err := ParseTags[T](opts...)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+// ParseTags is FromTags returning error that lists every unknown or malformed tag instead of panic
+func ParseTags[T any](opts ...TagOpt) (StructSchema[T], error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		panic(errors.Errorf("%s: not a struct", typ))
+	}
+
+	cfg := tagConfig{
+		key:     "ecto",
+		aliases: make(map[string]string),
+		tags:    make(map[string][]tagEntry),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+
+	b := tagBuilder{cfg: cfg, structs: make(map[reflect.Type]*tagFields)}
+	fields := b.structFields(typ).fields
+	if len(b.errs) > 0 {
+		return StructSchema[T]{}, errors.New(strings.Join(b.errs, "; "))
+	}
+	return Struct[T](fields), nil
+}
+
+var reservedTags = []string{"required", "required_if", "omitempty", "dive"}
+
+var tagRegistry = struct {
+	sync.RWMutex
+	tags map[string][]tagEntry
+}{tags: make(map[string][]tagEntry)}
+
+type tagEntry struct {
+	typ   reflect.Type
+	build func(param string) (tagTest, error)
+}
+
+type tagTest struct {
+	node TestNode
+	run  func(ctx context.Context, rv reflect.Value) bool
+}
+
+func validateTagName(name string) {
+	if lo.Contains(reservedTags, name) || name == "" || strings.ContainsAny(name, ",=") {
+		panic(errors.Errorf("invalid tag name %q", name))
+	}
+}
+
+func addTagEntry[T any](tags map[string][]tagEntry, name string, fn TagFunc[T]) {
+	typ := reflect.TypeFor[T]()
+	entry := tagEntry{
+		typ: typ,
+		build: func(param string) (tagTest, error) {
+			test, err := fn(param)
+			if err != nil {
+				return tagTest{}, err
+			}
+			return tagTest{
+				node: newTestNode(test),
+				run: func(ctx context.Context, rv reflect.Value) bool {
+					v := rv.Convert(typ).Interface().(T)
+					return test.RunContext(ctx, &v) == nil
+				},
+			}, nil
+		},
+	}
+	tags[name] = append(lo.Reject(tags[name], func(e tagEntry, _ int) bool { return e.typ == typ }), entry)
+}
+
+// tagRules are constraints of struct tags shared by schemas of every kind
+type tagRules struct {
+	required, omitZero bool
+	// requiredIf reports whether zero value is forbidden
+	requiredIf *tagTest
+	tests      []tagTest
+}
+
+// check runs rules against value, done reports whether there's nothing to process further
+func (r tagRules) check(ctx context.Context, rv reflect.Value) (done bool, err error) {
+	if rv.IsZero() {
+		if r.required || (r.requiredIf != nil && r.requiredIf.run(ctx, rv)) {
+			return true, ListError{errRequired}
+		}
+		if r.omitZero {
+			return true, nil
+		}
+	}
+
+	var errs ListError
+	for _, test := range r.tests {
+		if !test.run(ctx, rv) {
+			errs = append(errs, test.node.Error)
+		}
+	}
+	if len(errs) > 0 {
+		return true, errs
+	}
+	return false, nil
+}
+
+// describe lists conditional requirement (required_if) as a test
+func (r tagRules) describe(kind NodeKind, typ reflect.Type) Node {
+	node := Node{
+		Kind:     kind,
+		Type:     typ.String(),
+		Required: r.required,
+		OmitZero: r.omitZero,
+		Tests:    lo.Map(r.tests, func(test tagTest, _ int) TestNode { return test.node }),
+	}
+	if r.requiredIf != nil {
+		node.Tests = append([]TestNode{r.requiredIf.node}, node.Tests...)
+	}
+	// Like ones of hand-written schemas
+	if len(node.Tests) == 0 && (kind == KindPtr || kind == KindStruct) {
+		node.Tests = nil
+	}
+	return node
+}
+
+// tagAtomicSchema is AtomicSchema of a type known at runtime only
+type tagAtomicSchema struct {
+	tagRules
+	typ reflect.Type
+}
+
+func (s tagAtomicSchema) ForType() reflect.Type { return s.typ }
+
+func (s tagAtomicSchema) describe(*describer) Node { return s.tagRules.describe(KindAtomic, s.typ) }
+
+func (s tagAtomicSchema) process(ctx context.Context, ptr any) error {
+	_, err := s.check(ctx, reflect.ValueOf(ptr).Elem())
+	return err
+}
+
+func (s tagAtomicSchema) IsRequired() bool { return s.required || (!s.omitZero && len(s.tests) > 0) }
+
+func (s tagAtomicSchema) WithRequired(value bool) IAtomicOrPtrSchema {
+	s.required = value
+	s.omitZero = !value
+	return s
+}
+
+// tagPtrSchema is PtrSchema of a type known at runtime only. Tags except for required ones belong to elem
+type tagPtrSchema struct {
+	tagRules
+	typ  reflect.Type
+	elem Schema
+}
+
+func (s tagPtrSchema) ForType() reflect.Type { return s.typ }
+
+func (s tagPtrSchema) describe(d *describer) Node {
+	node := s.tagRules.describe(KindPtr, s.typ)
+	elem := s.elem.describe(d)
+	node.Elem = &elem
+	return node
+}
+
+func (s tagPtrSchema) process(ctx context.Context, ptr any) error {
+	rv := reflect.ValueOf(ptr).Elem()
+	if done, err := s.check(ctx, rv); done || rv.IsNil() {
+		return err
+	}
+	return s.elem.process(ctx, rv.Interface())
+}
+
+func (s tagPtrSchema) IsRequired() bool { return s.required }
+
+func (s tagPtrSchema) WithRequired(value bool) IAtomicOrPtrSchema {
+	s.required = value
+	return s
+}
+
+// tagSliceSchema is SliceSchema of a type known at runtime only
+type tagSliceSchema struct {
+	tagRules
+	typ  reflect.Type
+	elem Schema
+}
+
+func (s tagSliceSchema) ForType() reflect.Type { return s.typ }
+
+func (s tagSliceSchema) describe(d *describer) Node {
+	node := s.tagRules.describe(KindSlice, s.typ)
+	elem := s.elem.describe(d)
+	node.Elem = &elem
+	return node
+}
+
+func (s tagSliceSchema) process(ctx context.Context, ptr any) error {
+	rv := reflect.ValueOf(ptr).Elem()
+	if done, err := s.check(ctx, rv); done {
+		return err
+	}
+
+	var errs MapError
+	for i := range rv.Len() {
+		errs.Add(strconv.Itoa(i), s.elem.process(ctx, rv.Index(i).Addr().Interface()))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (s tagSliceSchema) Inner() Schema { return s.elem }
+
+func (s tagSliceSchema) WithInner(inner Schema) ISliceSchema {
+	if err := validateSchema(s.typ.Elem(), inner); err != nil {
+		panic(errors.Wrapf(err, "%s", s.typ))
+	}
+	s.elem = inner
+	return s
+}
+
+// tagStructSchema is StructSchema of a type known at runtime only
+type tagStructSchema struct {
+	tagRules
+	fields *tagFields
+}
+
+type tagFields struct {
+	typ    reflect.Type
+	fields M
+	meta   map[string]FieldMeta
+	// parent reports whether tests of fields need Parent
+	parent bool
+}
+
+// newTagFields validates fields like StructSchema does
+func newTagFields(typ reflect.Type, fields M) *tagFields {
+	self := &tagFields{typ: typ, fields: fields, meta: fieldsMeta(typ)}
+	for key, schema := range fields {
+		field, ok := typ.FieldByName(key)
+		if !ok {
+			panic(errors.Errorf("%s: Missing struct schema key: %s", typ, key))
+		}
+		if err := validateSchema(field.Type, schema); err != nil {
+			panic(errors.Wrapf(err, "%s: %s", typ, key))
+		}
+		parent, err := checkParent(typ, schema)
+		if err != nil {
+			panic(errors.Wrapf(err, "%s: %s", typ, key))
+		}
+		self.parent = self.parent || parent
+	}
+	return self
+}
+
+func (s tagStructSchema) ForType() reflect.Type { return s.fields.typ }
+
+func (s tagStructSchema) describe(d *describer) Node {
+	node := s.tagRules.describe(KindStruct, s.fields.typ)
+	if !d.enter(s.fields) {
+		node.Recursive = true
+		return node
+	}
+	defer d.leave(s.fields)
+	node.Fields = describeFields(d, s.fields.fields, s.fields.meta)
+	return node
+}
+
+func (s tagStructSchema) process(ctx context.Context, ptr any) error {
+	if done, err := s.check(ctx, reflect.ValueOf(ptr).Elem()); done {
+		return err
+	}
+	return processFields(ctx, ptr, s.fields.fields, s.fields.meta, s.fields.parent, nil, s.panicMissingKey)
+}
+
+func (s tagStructSchema) Fields() M { return s.fields.fields }
+
+func (s tagStructSchema) WithFields(fields M) IStructSchema {
+	s.fields = newTagFields(s.fields.typ, fields)
+	return s
+}
+
+func (s tagStructSchema) Meta() map[string]FieldMeta { return s.fields.meta }
+
+func (s tagStructSchema) CastToAny(src []byte, deserialize func([]byte, any) error, opts ...CastOpt) (any, error) {
+	ptr := reflect.New(s.fields.typ)
+	if err := deserialize(src, ptr.Interface()); err != nil {
+		return nil, errors.Wrapf(err, "deserialize %s into %s", src, s.fields.typ)
+	}
+
+	cfg := newCastConfig(opts)
+	if cfg.scrub {
+		ScrubAny(ptr.Interface())
+	}
+	err := s.process(cmp.Or(cfg.ctx, context.Background()), ptr.Interface())
+	return ptr.Elem().Interface(), err
+}
+
+func (s tagStructSchema) panicMissingKey(key string) {
+	panic(errors.Errorf("%s: Missing struct schema key: %s", s.fields.typ, key))
+}
+
+type tagBuilder struct {
+	cfg     tagConfig
+	structs map[reflect.Type]*tagFields
+	errs    []string
+}
+
+// structFields builds schemas of tagged fields. Result is cached before building, so recursive types refer to it
+func (b *tagBuilder) structFields(typ reflect.Type) *tagFields {
+	if cached, ok := b.structs[typ]; ok {
+		return cached
+	}
+
+	self := &tagFields{typ: typ, fields: make(M), meta: fieldsMeta(typ)}
+	b.structs[typ] = self
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		tag, ok := field.Tag.Lookup(b.cfg.key)
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+
+		var tags []string
+		if ok && tag != "" {
+			tags = strings.Split(tag, ",")
+		}
+		schema := b.build(typ, field, field.Type, tags)
+		if schema == nil {
+			continue
+		}
+		parent, err := checkParent(typ, schema)
+		if err != nil {
+			b.fail(typ, field, tag, err)
+		}
+		self.fields[field.Name] = schema
+		self.parent = self.parent || parent
+	}
+	return self
+}
+
+// build returns nil if there's nothing to process
+func (b *tagBuilder) build(parent reflect.Type, field reflect.StructField, typ reflect.Type, tags []string) Schema {
+	var elemTags []string
+	if i := lo.IndexOf(tags, "dive"); i >= 0 {
+		if typ.Kind() != reflect.Slice {
+			b.fail(parent, field, "dive", errors.Errorf("applicable to slices only, got %s", typ))
+		}
+		tags, elemTags = tags[:i], tags[i+1:]
+	}
+
+	var rules tagRules
+	for _, tag := range tags {
+		name, param, _ := strings.Cut(tag, "=")
+		name = cmp.Or(b.cfg.aliases[name], name)
+		switch {
+		case name == "required":
+			rules.required = true
+		case name == "required_if":
+			requiredIf, err := requiredIfTest(parent, param)
+			if err != nil {
+				b.fail(parent, field, tag, err)
+			}
+			rules.requiredIf = requiredIf
+		case name == "omitempty":
+			rules.omitZero = true
+		case typ.Kind() == reflect.Pointer:
+			elemTags = append(elemTags, tag)
+		default:
+			test, err := b.test(typ, name, param)
+			if err != nil {
+				b.fail(parent, field, tag, err)
+				continue
+			}
+			rules.tests = append(rules.tests, test)
+		}
+	}
+
+	var elem Schema
+	var fields *tagFields
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Slice:
+		elem = b.build(parent, field, typ.Elem(), elemTags)
+	case reflect.Struct:
+		if hasTags(typ, b.cfg.key, make(map[reflect.Type]bool)) {
+			fields = b.structFields(typ)
+		}
+	default:
+	}
+
+	if !rules.required && rules.requiredIf == nil && len(rules.tests) == 0 && elem == nil && fields == nil {
+		return nil
+	}
+	return newTagSchema(typ, rules, elem, fields)
+}
+
+// newTagSchema returns schema of kind of typ. Missing elem and fields are replaced with ones having no rules
+func newTagSchema(typ reflect.Type, rules tagRules, elem Schema, fields *tagFields) Schema {
+	if elem == nil && (typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice) {
+		elem = newTagSchema(typ.Elem(), tagRules{}, nil, nil)
+	}
+
+	switch typ.Kind() {
+	case reflect.Pointer:
+		return tagPtrSchema{tagRules: rules, typ: typ, elem: elem}
+	case reflect.Slice:
+		return tagSliceSchema{tagRules: rules, typ: typ, elem: elem}
+	case reflect.Struct:
+		if fields == nil {
+			fields = &tagFields{typ: typ, fields: make(M), meta: fieldsMeta(typ)}
+		}
+		return tagStructSchema{tagRules: rules, fields: fields}
+	default:
+		return tagAtomicSchema{tagRules: rules, typ: typ}
+	}
+}
+
+func (b *tagBuilder) fail(parent reflect.Type, field reflect.StructField, tag string, err error) {
+	b.errs = append(b.errs, fmt.Sprintf("%s.%s: tag %q: %s", parent, field.Name, tag, err))
+}
+
+// test looks up tags passed via WithTag first, then registered ones
+func (b *tagBuilder) test(typ reflect.Type, name, param string) (tagTest, error) {
+	if typ.Kind() == reflect.Slice && (name == "min" || name == "max" || name == "len") {
+		return sliceLenTest(name, param)
+	}
+
+	tagRegistry.RLock()
+	entries := slices.Concat(b.cfg.tags[name], tagRegistry.tags[name])
+	tagRegistry.RUnlock()
+	if len(entries) == 0 {
+		return tagTest{}, errors.New("unknown tag, probably package registering it (ex. ecto/strings) isn't imported")
+	}
+
+	entry, ok := lo.Find(entries, func(e tagEntry) bool { return e.typ == typ })
+	if !ok {
+		entry, ok = lo.Find(entries, func(e tagEntry) bool {
+			return kindFamily(e.typ.Kind()) == kindFamily(typ.Kind()) && typ.ConvertibleTo(e.typ)
+		})
+	}
+	if !ok {
+		return tagTest{}, errors.Errorf("not applicable to %s", typ)
+	}
+	return entry.build(param)
+}
+
+// requiredIfTest parses pairs of sibling field names and their values in string representation.
+// The test reports whether value is required
+func requiredIfTest(parent reflect.Type, param string) (*tagTest, error) {
+	args := strings.Fields(param)
+	if len(args) == 0 || len(args)%2 != 0 {
+		return nil, errors.New("expected pairs of field name and value")
+	}
+	fields := make(map[string]string, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		if _, ok := parent.FieldByName(args[i]); !ok {
+			return nil, errors.Errorf("unknown field %s", args[i])
+		}
+		fields[args[i]] = args[i+1]
+	}
+
+	node := TestNode{Code: "ecto.RequiredIf", Params: map[string]any{"fields": fields}, Error: errRequired, contextual: true}
+	return &tagTest{node: node, run: func(ctx context.Context, _ reflect.Value) bool {
+		rv := reflect.Indirect(reflect.ValueOf(Parent(ctx)))
+		if !rv.IsValid() {
+			return false
+		}
+		for i := 0; i < len(args); i += 2 {
+			field := reflect.Indirect(rv.FieldByName(args[i]))
+			if !field.IsValid() || fmt.Sprint(field.Interface()) != args[i+1] {
+				return false
+			}
+		}
+		return true
+	}}, nil
+}
+
+// hasTags reports whether struct type has tagged fields directly or via nested types
+func hasTags(typ reflect.Type, key string, visited map[reflect.Type]bool) bool {
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || visited[typ] {
+		return false
+	}
+
+	visited[typ] = true
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if tag := field.Tag.Get(key); (tag != "" && tag != "-") || hasTags(field.Type, key, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+func sliceLenTest(name, param string) (tagTest, error) {
+	length, err := strconv.ParseUint(param, 10, 0)
+	if err != nil {
+		return tagTest{}, errors.Wrap(err, "parse length")
+	}
+
+	test := tagTest{node: TestNode{Params: map[string]any{"length": uint(length)}}}
+	var valid func(l int) bool
+	switch name {
+	case "min":
+		test.node.Code, test.node.Error = "slices.Min", Errorf("must contain at least %d items", length)
+		valid = func(l int) bool { return l >= int(length) }
+	case "max":
+		test.node.Code, test.node.Error = "slices.Max", Errorf("must contain at most %d items", length)
+		valid = func(l int) bool { return l <= int(length) }
+	default:
+		test.node.Code, test.node.Error = "slices.Len", Errorf("must contain exactly %d items", length)
+		valid = func(l int) bool { return l == int(length) }
+	}
+	test.run = func(_ context.Context, rv reflect.Value) bool { return valid(rv.Len()) }
+	return test, nil
+}
+
+// kindFamily groups kinds convertible to each other without loss of meaning
+func kindFamily(kind reflect.Kind) reflect.Kind {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int64
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint64
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	default:
+		return kind
+	}
+}
+
+func init() {
+	RegisterTag("oneof", func(param string) (Test[string], error) { return OneOf(strings.Fields(param)...), nil })
+
+	registerNumberTags("ints", func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) })
+	registerNumberTags("ints", func(s string) (uint64, error) { return strconv.ParseUint(s, 10, 64) })
+	registerNumberTags("floats", func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+}
+
+// registerNumberTags registers tags with codes of tests of pkg (see Test.Code)
+func registerNumberTags[T int64 | uint64 | float64](pkg string, parse func(string) (T, error)) {
+	RegisterTag("min", func(param string) (Test[T], error) {
+		value, err := parse(param)
+		if err != nil {
+			return Test[T]{}, errors.Wrap(err, "parse number")
+		}
+		return Test[T]{
+			Code:   pkg + ".Min",
+			Params: map[string]any{"value": value},
+			Error:  Errorf("must be %v minimum", value),
+			Func:   func(v *T) bool { return *v >= value },
+		}, nil
+	})
+	RegisterTag("max", func(param string) (Test[T], error) {
+		value, err := parse(param)
+		if err != nil {
+			return Test[T]{}, errors.Wrap(err, "parse number")
+		}
+		return Test[T]{
+			Code:   pkg + ".Max",
+			Params: map[string]any{"value": value},
+			Error:  Errorf("must be %v maximum", value),
+			Func:   func(v *T) bool { return *v <= value },
+		}, nil
+	})
+	RegisterTag("oneof", func(param string) (Test[T], error) {
+		fields := strings.Fields(param)
+		variants := make([]T, len(fields))
+		for i, field := range fields {
+			value, err := parse(field)
+			if err != nil {
+				return Test[T]{}, errors.Wrap(err, "parse number")
+			}
+			variants[i] = value
+		}
+		return OneOf(variants...), nil
+	})
+}
@@ -0,0 +1,229 @@
+package ecto_test
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/egsam98/ecto"
+	_ "github.com/egsam98/ecto/network"
+	ectosl "github.com/egsam98/ecto/slices"
+	ectos "github.com/egsam98/ecto/strings"
+	"github.com/egsam98/ecto/validator"
+)
+
+type Tagged struct {
+	Name     string    `json:"name" ecto:"required,min=3,max=50"`
+	Kind     Hello     `json:"kind" ecto:"oneof=a b"`
+	Site     string    `json:"site" ecto:"omitempty,url"`
+	Age      int32     `json:"age" ecto:"min=18,max=150"`
+	Score    *float64  `json:"score" ecto:"required,min=0,max=1"`
+	Tags     []string  `json:"tags" ecto:"min=1,dive,min=2"`
+	Children []*Tagged `json:"children"`
+	Host     string    `json:"host" ecto:"hostname"`
+	Nick     string    `json:"nick"`
+	Ignored  string    `ecto:"-"`
+}
+
+func TestFromTags(t *testing.T) {
+	schema := ecto.FromTags[Tagged]()
+	assert.ElementsMatch(t, []string{"Name", "Kind", "Site", "Age", "Score", "Tags", "Children", "Host"},
+		lo.Keys(schema.Fields()))
+
+	valid := Tagged{
+		Name:  "John",
+		Kind:  "a",
+		Age:   20,
+		Score: lo.ToPtr(0.5),
+		Tags:  []string{"go"},
+		Host:  "example.com",
+	}
+	assert.NoError(t, schema.Process(&valid))
+
+	invalid := valid
+	invalid.Name = "Jo"
+	invalid.Kind = "c"
+	invalid.Site = "wikipedia"
+	invalid.Age = 10
+	invalid.Score = lo.ToPtr(1.5)
+	invalid.Tags = []string{"go", "x"}
+	invalid.Children = []*Tagged{nil, {Score: lo.ToPtr(0.0)}}
+	invalid.Host = "-"
+	err := schema.Process(&invalid)
+	require.Error(t, err)
+	assert.JSONEq(t, `{
+		"name": ["must be at least 3 characters long"],
+		"kind": ["must be one of [a b]"],
+		"site": ["invalid URL"],
+		"age": ["must be 18 minimum"],
+		"score": ["must be 1 maximum"],
+		"tags": {"1": ["must be at least 2 characters long"]},
+		"children": {"1": {
+			"name": ["required"],
+			"kind": ["must be one of [a b]"],
+			"age": ["must be 18 minimum"],
+			"tags": ["must contain at least 1 items"],
+			"host": ["invalid hostname"]
+		}},
+		"host": ["invalid hostname"]
+	}`, err.Error())
+
+	t.Run("extend", func(t *testing.T) {
+		schema := schema.Extend(ecto.M{"Nick": ecto.String().Required().Test(ectos.ASCII())})
+		invalid := valid
+		invalid.Score = nil
+		invalid.Nick = "ник"
+		assert.EqualError(t, schema.Process(&invalid), `{"nick":["must contain only ASCII characters"],"score":["required"]}`)
+	})
+
+	t.Run("same as hand-written", func(t *testing.T) {
+		type Profile struct {
+			Name  string   `json:"name" ecto:"required,min=3"`
+			Site  *string  `json:"site" ecto:"url"`
+			Email *string  `json:"email" ecto:"required"`
+			Tags  []string `json:"tags" ecto:"max=2,dive,min=2"`
+			Codes []string `json:"codes" ecto:"min=1"`
+		}
+		tagged := ecto.FromTags[Profile]()
+		assert.Equal(t, ecto.Inspect(ecto.Struct[Profile](ecto.M{
+			"Name":  ecto.String().Required().Test(ectos.Min(3)),
+			"Site":  ecto.Ptr[string](ecto.String().Test(ectos.URL())),
+			"Email": ecto.Ptr[string](ecto.String()).Required(),
+			"Tags":  ecto.Slice[[]string](ecto.String().Test(ectos.Min(2))).Test(ectosl.Max[[]string](2)),
+			"Codes": ecto.Slice[[]string](ecto.String()).Test(ectosl.Min[[]string](1)),
+		})), ecto.Inspect(tagged))
+
+		assert.True(t, tagged.Fields()["Email"].(ecto.IAtomicOrPtrSchema).IsRequired())
+		assert.Implements(t, (*ecto.ISliceSchema)(nil), tagged.Fields()["Tags"])
+		nested := schema.Fields()["Children"].(ecto.ISliceSchema).Inner().(ecto.IAtomicOrPtrSchema)
+		assert.False(t, nested.IsRequired())
+	})
+
+	t.Run("invalid tags", func(t *testing.T) {
+		assert.Panics(t, func() {
+			ecto.FromTags[struct {
+				A string `ecto:"unknown"`
+			}]()
+		})
+		assert.Panics(t, func() {
+			ecto.FromTags[struct {
+				A int `ecto:"min=a"`
+			}]()
+		})
+		assert.Panics(t, func() {
+			ecto.FromTags[struct {
+				A bool `ecto:"min=1"`
+			}]()
+		})
+		assert.Panics(t, func() {
+			ecto.FromTags[struct {
+				A string `ecto:"dive"`
+			}]()
+		})
+	})
+}
+
+func TestRegisterTag(t *testing.T) {
+	ecto.RegisterTag("even", ecto.NoParamTag(func() ecto.Test[int64] {
+		return ecto.Test[int64]{Error: "must be even", Func: func(v *int64) bool { return *v%2 == 0 }}
+	}))
+	assert.Panics(t, func() { ecto.RegisterTag("required", ecto.NoParamTag(ectos.URL)) })
+
+	type Numbers struct {
+		A uint8 `ecto:"even"`
+		B []int `ecto:"dive,even"`
+	}
+	assert.Panics(t, func() { ecto.FromTags[Numbers]() })
+
+	type Ints struct {
+		B []int `ecto:"dive,even"`
+	}
+	err := ecto.FromTags[Ints]().Process(&Ints{B: []int{2, 3}})
+	assert.EqualError(t, err, `{"B":{"1":["must be even"]}}`)
+}
+
+type Legacy struct {
+	Email    string   `json:"email" validate:"required,email"`
+	Count    uint16   `json:"count" validate:"gte=1,lt=10"`
+	Kind     string   `json:"kind" validate:"oneof=person company"`
+	Company  *string  `json:"company" validate:"required_if=Kind company,omitempty,min=2"`
+	Codes    []string `json:"codes" validate:"omitempty,len=2,dive,len=3,uppercase"`
+	Nickname string   `json:"nickname" validate:"omitempty,alphanum,gt=2"`
+}
+
+func TestValidatorFromTags(t *testing.T) {
+	schema, err := validator.FromTags[Legacy]()
+	require.NoError(t, err)
+	assert.NoError(t, schema.Process(&Legacy{Email: "a@example.com", Count: 1, Kind: "person"}))
+
+	err = schema.Process(&Legacy{
+		Email:    "example.com",
+		Count:    10,
+		Kind:     "company",
+		Codes:    []string{"USD", "eur", "RUB"},
+		Nickname: "a_",
+	})
+	require.Error(t, err)
+	assert.JSONEq(t, `{
+		"email": ["invalid email address"],
+		"count": ["must be less than 10"],
+		"company": ["required"],
+		"codes": ["must contain exactly 2 items"],
+		"nickname": ["must contain only letters and digits", "must be longer than 2 characters"]
+	}`, err.Error())
+
+	err = schema.Process(&Legacy{Email: "a@example.com", Kind: "company", Company: lo.ToPtr("A"), Codes: []string{"USD", "eur"}})
+	require.Error(t, err)
+	assert.JSONEq(t, `{
+		"count": ["must be 1 minimum"],
+		"company": ["must be at least 2 characters long"],
+		"codes": {"1": ["must be uppercase"]}
+	}`, err.Error())
+
+	t.Run("existing tags", func(t *testing.T) {
+		schema, err := validator.FromTags[Data]()
+		require.NoError(t, err)
+		assert.EqualError(t, schema.Process(&Data{F: []F{{}}}), `{"C":{"C1":["required"]},"f":{"0":{"f1":["required"]}}}`)
+	})
+
+	t.Run("upstream semantics", func(t *testing.T) {
+		type Contact struct {
+			Phone string `validate:"omitempty,e164"`
+			Name  string `validate:"alpha"`
+			Code  string `validate:"alphanum"`
+			Note  string `validate:"printascii"`
+			Kind  string `validate:"oneof='private person' company"`
+		}
+		schema, err := validator.FromTags[Contact]()
+		require.NoError(t, err)
+		assert.NoError(t, schema.Process(&Contact{Phone: "+14155552671", Name: "a", Code: "1", Note: " ", Kind: "private person"}))
+		assert.NoError(t, schema.Process(&Contact{Phone: "+1234567", Name: "a", Code: "1", Note: " ", Kind: "company"}))
+
+		err = schema.Process(&Contact{Phone: "14155552671", Kind: "private"})
+		require.Error(t, err)
+		assert.JSONEq(t, `{
+			"Phone": ["invalid phone number"],
+			"Name": ["must contain only letters"],
+			"Code": ["must contain only letters and digits"],
+			"Note": ["must contain only printable ASCII characters"],
+			"Kind": ["must be one of [private person company]"]
+		}`, err.Error())
+		for _, phone := range []string{"+123456", "+1234567890123456", "+1 415 555 2671"} {
+			assert.Error(t, schema.Process(&Contact{Phone: phone, Name: "a", Code: "1", Note: " ", Kind: "company"}), phone)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := validator.FromTags[struct {
+			A string `validate:"required,eqfield=B"`
+			B string `validate:"email|url"`
+			C int    `validate:"required_if=D 1"`
+		}]()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `A: tag "eqfield=B"`)
+		assert.Contains(t, err.Error(), `B: tag "email|url"`)
+		assert.Contains(t, err.Error(), `C: tag "required_if=D 1": unknown field D`)
+	})
+}
@@ -0,0 +1,170 @@
+package times
+
+import (
+	"context"
+	"time"
+
+	"github.com/egsam98/errors"
+	"github.com/samber/lo"
+
+	"github.com/egsam98/ecto"
+)
+
+// Clock provides current time for tests relative to "now". Tests call it on every run, so
+// a fixed clock may be used to get deterministic results, ex. times.Clock(fixedNow).NotInFuture()
+type Clock func() time.Time
+
+// WithClock makes package-level tests relative to current moment (NotInFuture, NotInPast, MinAge, MaxAge)
+// resolve it by clock during processing, ex. schema.ProcessContext(times.WithClock(ctx, fixedNow), &v).
+// They use time.Now otherwise
+func WithClock(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, clock)
+}
+
+type clockKey struct{}
+
+// Before restricts time with upper exclusive bound
+func Before(value time.Time) ecto.Test[time.Time] {
+	return ecto.Test[time.Time]{
+		Code:   "times.Before",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Error("must be before " + value.Format(time.RFC3339)),
+		Func:   func(v *time.Time) bool { return v.Before(value) },
+	}
+}
+
+// After restricts time with lower exclusive bound
+func After(value time.Time) ecto.Test[time.Time] {
+	return ecto.Test[time.Time]{
+		Code:   "times.After",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Error("must be after " + value.Format(time.RFC3339)),
+		Func:   func(v *time.Time) bool { return v.After(value) },
+	}
+}
+
+// Between restricts time with both inclusive bounds
+func Between(from, to time.Time) ecto.Test[time.Time] {
+	if from.After(to) {
+		panic(errors.Errorf("invalid range [%s, %s]", from, to))
+	}
+	return ecto.Test[time.Time]{
+		Code:   "times.Between",
+		Params: map[string]any{"from": from, "to": to},
+		Error:  ecto.Errorf("must be between %s and %s", from.Format(time.RFC3339), to.Format(time.RFC3339)),
+		Func:   func(v *time.Time) bool { return !v.Before(from) && !v.After(to) },
+	}
+}
+
+// Weekday restricts time to be on one of days of week
+func Weekday(days ...time.Weekday) ecto.Test[time.Time] {
+	set := lo.Keyify(days)
+	return ecto.Test[time.Time]{
+		Code:   "times.Weekday",
+		Params: map[string]any{"days": days},
+		Error:  ecto.Errorf("weekday must be one of %v", days),
+		Func:   func(v *time.Time) bool { return lo.HasKey(set, v.Weekday()) },
+	}
+}
+
+// NotInFuture forbids time to be after current moment (see WithClock)
+func NotInFuture() ecto.Test[time.Time] { return notInFuture().withContext() }
+
+// NotInPast forbids time to be before current moment (see WithClock)
+func NotInPast() ecto.Test[time.Time] { return notInPast().withContext() }
+
+// MinAge restricts birthdate to full years passed until current moment with lower inclusive bound (see WithClock)
+func MinAge(years uint) ecto.Test[time.Time] { return minAge(years).withContext() }
+
+// MaxAge restricts birthdate to full years passed until current moment with upper inclusive bound (see WithClock)
+func MaxAge(years uint) ecto.Test[time.Time] { return maxAge(years).withContext() }
+
+// NotInFuture forbids time to be after current moment
+func (c Clock) NotInFuture() ecto.Test[time.Time] { return notInFuture().withClock(c) }
+
+// NotInPast forbids time to be before current moment
+func (c Clock) NotInPast() ecto.Test[time.Time] { return notInPast().withClock(c) }
+
+// MinAge restricts birthdate to full years passed until current moment with lower inclusive bound
+func (c Clock) MinAge(years uint) ecto.Test[time.Time] { return minAge(years).withClock(c) }
+
+// MaxAge restricts birthdate to full years passed until current moment with upper inclusive bound
+func (c Clock) MaxAge(years uint) ecto.Test[time.Time] { return maxAge(years).withClock(c) }
+
+// relativeTest is a test of time compared with current moment
+type relativeTest struct {
+	ecto.Test[time.Time]
+	check func(v, now time.Time) bool
+}
+
+func (t relativeTest) withClock(clock Clock) ecto.Test[time.Time] {
+	t.Func = func(v *time.Time) bool { return t.check(*v, clock()) }
+	return t.Test
+}
+
+func (t relativeTest) withContext() ecto.Test[time.Time] {
+	t.FuncContext = func(ctx context.Context, v *time.Time) bool {
+		clock, ok := ctx.Value(clockKey{}).(Clock)
+		if !ok {
+			clock = time.Now
+		}
+		return t.check(*v, clock())
+	}
+	return t.Test
+}
+
+func notInFuture() relativeTest {
+	return relativeTest{
+		Test:  ecto.Test[time.Time]{Code: "times.NotInFuture", Error: "must not be in the future"},
+		check: func(v, now time.Time) bool { return !v.After(now) },
+	}
+}
+
+func notInPast() relativeTest {
+	return relativeTest{
+		Test:  ecto.Test[time.Time]{Code: "times.NotInPast", Error: "must not be in the past"},
+		check: func(v, now time.Time) bool { return !v.Before(now) },
+	}
+}
+
+func minAge(years uint) relativeTest {
+	return relativeTest{
+		Test: ecto.Test[time.Time]{
+			Code:   "times.MinAge",
+			Params: map[string]any{"years": years},
+			Error:  ecto.Errorf("must be at least %d years old", years),
+		},
+		check: func(v, now time.Time) bool { return !v.AddDate(int(years), 0, 0).After(now) },
+	}
+}
+
+func maxAge(years uint) relativeTest {
+	return relativeTest{
+		Test: ecto.Test[time.Time]{
+			Code:   "times.MaxAge",
+			Params: map[string]any{"years": years},
+			Error:  ecto.Errorf("must be at most %d years old", years),
+		},
+		check: func(v, now time.Time) bool { return v.AddDate(int(years)+1, 0, 0).After(now) },
+	}
+}
+
+// MinDuration restricts duration with lower inclusive bound
+func MinDuration(value time.Duration) ecto.Test[time.Duration] {
+	return ecto.Test[time.Duration]{
+		Code:   "times.MinDuration",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Error("must be " + value.String() + " minimum"),
+		Func:   func(v *time.Duration) bool { return *v >= value },
+	}
+}
+
+// MaxDuration restricts duration with upper inclusive bound
+func MaxDuration(value time.Duration) ecto.Test[time.Duration] {
+	return ecto.Test[time.Duration]{
+		Code:   "times.MaxDuration",
+		Params: map[string]any{"value": value},
+		Error:  ecto.Error("must be " + value.String() + " maximum"),
+		Func:   func(v *time.Duration) bool { return *v <= value },
+	}
+}
@@ -0,0 +1,39 @@
+package uuids
+
+import (
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/egsam98/ecto"
+)
+
+// Version restricts UUID to be one of versions (ex. 4 or 7)
+func Version(versions ...uuid.Version) ecto.Test[uuid.UUID] {
+	set := lo.Keyify(versions)
+	return ecto.Test[uuid.UUID]{
+		Code:   "uuids.Version",
+		Params: map[string]any{"versions": versions},
+		Error:  ecto.Errorf("UUID version must be one of %d", versions),
+		Func:   func(v *uuid.UUID) bool { return lo.HasKey(set, v.Version()) },
+	}
+}
+
+// Variant restricts UUID to be one of variants (ex. uuid.RFC4122)
+func Variant(variants ...uuid.Variant) ecto.Test[uuid.UUID] {
+	set := lo.Keyify(variants)
+	return ecto.Test[uuid.UUID]{
+		Code:   "uuids.Variant",
+		Params: map[string]any{"variants": variants},
+		Error:  ecto.Errorf("UUID variant must be one of %v", variants),
+		Func:   func(v *uuid.UUID) bool { return lo.HasKey(set, v.Variant()) },
+	}
+}
+
+// NotNil forbids nil UUID (all zeros)
+func NotNil() ecto.Test[uuid.UUID] {
+	return ecto.Test[uuid.UUID]{
+		Code:  "uuids.NotNil",
+		Error: "must not be nil UUID",
+		Func:  func(v *uuid.UUID) bool { return *v != uuid.Nil },
+	}
+}
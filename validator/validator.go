@@ -0,0 +1,191 @@
+// Package validator translates `validate` struct tags of github.com/go-playground/validator into ecto schemas,
+// so DTOs are migrated incrementally without rewriting their tags. Supported rules:
+//   - required, required_if, omitempty, dive
+//   - min, max, len, gt, gte, lt, lte, eq, ne, oneof
+//   - email, url, ip, ipv4, ipv6, cidr, hostname, hostname_rfc1123, fqdn, mac, hostname_port, base64,
+//     iso4217, iso3166_1_alpha2, e164, uuid
+//   - alpha, alphanum, numeric, ascii, printascii, lowercase, uppercase, contains, excludes, startswith, endswith
+//
+// Variants of oneof may be quoted to contain spaces, ex. `validate:"oneof='a b' c"`
+//
+// Cross-field rules except for required_if, "|" alternatives, map rules (keys, endkeys) and escaped commas
+// aren't supported and reported by FromTags
+package validator
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/egsam98/errors"
+	"github.com/google/uuid"
+
+	"github.com/egsam98/ecto"
+	_ "github.com/egsam98/ecto/network"
+	_ "github.com/egsam98/ecto/strings"
+)
+
+var (
+	numericRegex = regexp.MustCompile(`^[-+]?[0-9]+(?:\.[0-9]+)?$`)
+	e164Regex    = regexp.MustCompile(`^\+[1-9]?[0-9]{7,14}$`)
+	oneofRegex   = regexp.MustCompile(`'[^']*'|\S+`)
+)
+
+var tagOpts = slices.Concat(
+	[]ecto.TagOpt{
+		ecto.TagKey("validate"),
+		ecto.TagAlias("gte", "min"),
+		ecto.TagAlias("lte", "max"),
+		ecto.TagAlias("hostname_rfc1123", "hostname"),
+		ecto.TagAlias("hostname_port", "hostport"),
+		ecto.TagAlias("iso4217", "currency"),
+		ecto.TagAlias("iso3166_1_alpha2", "country"),
+	},
+	stringTags(),
+	numberTags(func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) }),
+	numberTags(func(s string) (uint64, error) { return strconv.ParseUint(s, 10, 64) }),
+	numberTags(func(s string) (float64, error) { return strconv.ParseFloat(s, 64) }),
+)
+
+// FromTags builds ecto.StructSchema from `validate` struct tags. Returns error listing every unsupported rule.
+// Options are applied after the translation ones, ex. ecto.WithTag for custom validations
+func FromTags[T any](opts ...ecto.TagOpt) (ecto.StructSchema[T], error) {
+	return ecto.ParseTags[T](slices.Concat(tagOpts, opts)...)
+}
+
+func stringTags() []ecto.TagOpt {
+	return []ecto.TagOpt{
+		ecto.WithTag("gt", stringLength("must be longer than %d characters", func(l, p int) bool { return l > p })),
+		ecto.WithTag("lt", stringLength("must be shorter than %d characters", func(l, p int) bool { return l < p })),
+		ecto.WithTag("eq", func(param string) (ecto.Test[string], error) {
+			return ecto.Test[string]{
+				Error: ecto.Error("must be equal to " + param),
+				Func:  func(v *string) bool { return *v == param },
+			}, nil
+		}),
+		ecto.WithTag("ne", func(param string) (ecto.Test[string], error) {
+			return ecto.Test[string]{
+				Error: ecto.Error("must not be equal to " + param),
+				Func:  func(v *string) bool { return *v != param },
+			}, nil
+		}),
+		ecto.WithTag("contains", stringParam("must contain %q", strings.Contains)),
+		ecto.WithTag("excludes", stringParam("must not contain %q", func(s, p string) bool {
+			return !strings.Contains(s, p)
+		})),
+		ecto.WithTag("startswith", stringParam("must start with %q", strings.HasPrefix)),
+		ecto.WithTag("endswith", stringParam("must end with %q", strings.HasSuffix)),
+		ecto.WithTag("oneof", func(param string) (ecto.Test[string], error) {
+			variants := oneofRegex.FindAllString(param, -1)
+			for i, variant := range variants {
+				variants[i] = strings.Trim(variant, "'")
+			}
+			return ecto.OneOf(variants...), nil
+		}),
+		ecto.WithTag("e164", ecto.NoParamTag(func() ecto.Test[string] {
+			return ecto.Test[string]{
+				Error: "invalid phone number",
+				Func:  func(v *string) bool { return e164Regex.MatchString(*v) },
+			}
+		})),
+		ecto.WithTag("uuid", ecto.NoParamTag(func() ecto.Test[string] {
+			return ecto.Test[string]{
+				Error: "invalid UUID",
+				Func:  func(v *string) bool { return len(*v) == 36 && uuid.Validate(*v) == nil },
+			}
+		})),
+		ecto.WithTag("alpha", charset("must contain only letters", func(c byte) bool {
+			return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+		})),
+		ecto.WithTag("alphanum", charset("must contain only letters and digits", func(c byte) bool {
+			return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+		})),
+		ecto.WithTag("printascii", charset("must contain only printable ASCII characters", func(c byte) bool {
+			return ' ' <= c && c <= '~'
+		})),
+		ecto.WithTag("numeric", ecto.NoParamTag(func() ecto.Test[string] {
+			return ecto.Test[string]{
+				Error: "must be numeric",
+				Func:  func(v *string) bool { return numericRegex.MatchString(*v) },
+			}
+		})),
+		ecto.WithTag("lowercase", ecto.NoParamTag(func() ecto.Test[string] {
+			return ecto.Test[string]{
+				Error: "must be lowercase",
+				Func:  func(v *string) bool { return strings.ToLower(*v) == *v },
+			}
+		})),
+		ecto.WithTag("uppercase", ecto.NoParamTag(func() ecto.Test[string] {
+			return ecto.Test[string]{
+				Error: "must be uppercase",
+				Func:  func(v *string) bool { return strings.ToUpper(*v) == *v },
+			}
+		})),
+	}
+}
+
+func numberTags[T int64 | uint64 | float64](parse func(string) (T, error)) []ecto.TagOpt {
+	return []ecto.TagOpt{
+		ecto.WithTag("gt", number(parse, "must be greater than %v", func(v, p T) bool { return v > p })),
+		ecto.WithTag("lt", number(parse, "must be less than %v", func(v, p T) bool { return v < p })),
+		ecto.WithTag("eq", number(parse, "must be equal to %v", func(v, p T) bool { return v == p })),
+		ecto.WithTag("len", number(parse, "must be equal to %v", func(v, p T) bool { return v == p })),
+		ecto.WithTag("ne", number(parse, "must not be equal to %v", func(v, p T) bool { return v != p })),
+	}
+}
+
+func number[T int64 | uint64 | float64](parse func(string) (T, error), format string, fn func(v, param T) bool) ecto.TagFunc[T] {
+	return func(param string) (ecto.Test[T], error) {
+		value, err := parse(param)
+		if err != nil {
+			return ecto.Test[T]{}, errors.Wrap(err, "parse number")
+		}
+		return ecto.Test[T]{
+			Error: ecto.Errorf(format, value),
+			Func:  func(v *T) bool { return fn(*v, value) },
+		}, nil
+	}
+}
+
+func stringLength(format string, fn func(length, param int) bool) ecto.TagFunc[string] {
+	return func(param string) (ecto.Test[string], error) {
+		length, err := strconv.ParseUint(param, 10, 0)
+		if err != nil {
+			return ecto.Test[string]{}, errors.Wrap(err, "parse length")
+		}
+		return ecto.Test[string]{
+			Error: ecto.Errorf(format, length),
+			Func:  func(v *string) bool { return fn(utf8.RuneCountInString(*v), int(length)) },
+		}, nil
+	}
+}
+
+func stringParam(format string, fn func(s, param string) bool) ecto.TagFunc[string] {
+	return func(param string) (ecto.Test[string], error) {
+		return ecto.Test[string]{
+			Error: ecto.Errorf(format, param),
+			Func:  func(v *string) bool { return fn(*v, param) },
+		}, nil
+	}
+}
+
+func charset(msg ecto.Error, valid func(c byte) bool) ecto.TagFunc[string] {
+	return ecto.NoParamTag(func() ecto.Test[string] {
+		return ecto.Test[string]{
+			Error: msg,
+			Func: func(v *string) bool {
+				if *v == "" {
+					return false
+				}
+				for i := range len(*v) {
+					if !valid((*v)[i]) {
+						return false
+					}
+				}
+				return true
+			},
+		}
+	})
+}